@@ -0,0 +1,150 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"it-planet-task/helpers"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/permission"
+	"it-planet-task/internal/app/validator/AccountValidator"
+	"log"
+	"os"
+	"text/tabwriter"
+)
+
+const adminUsage = "usage: admin create|reset-password|list [flags]"
+
+// runAdminCommand Управление аккаунтами администраторов без HTTP API, например для создания первого администратора
+func runAdminCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, adminUsage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "create":
+		adminCreate(args[1:])
+	case "reset-password":
+		adminResetPassword(args[1:])
+	case "list":
+		adminList(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown admin command %s\n", args[0])
+		fmt.Fprintln(os.Stderr, adminUsage)
+		os.Exit(2)
+	}
+}
+
+// adminCreate Создание аккаунта. Если пароль не передан, генерируется случайный и выводится один раз
+func adminCreate(args []string) {
+	flags := flag.NewFlagSet("admin create", flag.ExitOnError)
+	email := flags.String("email", "", "account email")
+	accountPassword := flags.String("password", "", "account password, generated if empty")
+	firstName := flags.String("first-name", "Admin", "account first name")
+	lastName := flags.String("last-name", "Admin", "account last name")
+	role := flags.String("role", "ADMIN", "account role")
+	_ = flags.Parse(args)
+
+	generated := *accountPassword == ""
+	if generated {
+		*accountPassword = generatePassword()
+	}
+
+	account := &entity.Account{
+		FirstName: *firstName,
+		LastName:  *lastName,
+		Email:     *email,
+		Password:  *accountPassword,
+		Role:      *role,
+	}
+	permissionService := permission.NewPermissionService(permission.NewRolesFromConfig())
+	httpErr := AccountValidator.ValidateAccount(account, permissionService.Roles())
+	if httpErr != nil {
+		log.Fatal("admin create: ", httpErr.Err)
+	}
+
+	accountService := newAccountService()
+	if accountService.IsAlreadyExists(account) {
+		log.Fatalf("admin create: account %s already exists", account.Email)
+	}
+
+	created, err := accountService.Create(account)
+	if err != nil {
+		log.Fatal("admin create: ", err)
+	}
+
+	log.Printf("Created account %d %s with role %s", created.Id, created.Email, created.Role)
+	if generated {
+		fmt.Println(*accountPassword)
+	}
+}
+
+// adminResetPassword Установка нового пароля и отзыв refresh токенов аккаунта
+func adminResetPassword(args []string) {
+	flags := flag.NewFlagSet("admin reset-password", flag.ExitOnError)
+	email := flags.String("email", "", "account email")
+	accountPassword := flags.String("password", "", "new password, generated if empty")
+	_ = flags.Parse(args)
+
+	generated := *accountPassword == ""
+	if generated {
+		*accountPassword = generatePassword()
+	}
+
+	accountService := newAccountService()
+	account, err := accountService.GetByEmail(&entity.Account{Email: *email})
+	if err != nil || account.Id == 0 {
+		log.Fatalf("admin reset-password: account %s does not exists", *email)
+	}
+
+	err = accountService.SetPassword(account.Id, *accountPassword)
+	if err != nil {
+		log.Fatal("admin reset-password: ", err)
+	}
+
+	authRepo := repository.NewAuthRepository(helpers.GetConnectionOrCreateAndGet())
+	err = authRepo.RevokeAccountRefreshTokens(account.Id)
+	if err != nil {
+		log.Fatal("admin reset-password: ", err)
+	}
+
+	log.Printf("Password of account %d %s is reset", account.Id, account.Email)
+	if generated {
+		fmt.Println(*accountPassword)
+	}
+}
+
+// adminList Вывод аккаунтов с указанной ролью
+func adminList(args []string) {
+	flags := flag.NewFlagSet("admin list", flag.ExitOnError)
+	role := flags.String("role", "ADMIN", "account role, empty for all accounts")
+	_ = flags.Parse(args)
+
+	accountRepo := repository.NewAccountRepository(helpers.GetConnectionOrCreateAndGet())
+	accounts, err := accountRepo.GetAll()
+	if err != nil {
+		log.Fatal("admin list: ", err)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tEMAIL\tNAME\tROLE\tSTATUS")
+	for _, account := range *accounts {
+		if *role != "" && account.Role != *role {
+			continue
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s %s\t%s\t%s\n", account.Id, account.Email, account.FirstName, account.LastName, account.Role, account.Status)
+	}
+	_ = writer.Flush()
+}
+
+// generatePassword Случайный пароль для аккаунтов, создаваемых из командной строки
+func generatePassword() string {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"it-planet-task/helpers"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/accountcache"
+	"it-planet-task/internal/app/service/audit"
+	"it-planet-task/internal/app/service/password"
+	"log"
+	"os"
+)
+
+// runCommand Выполнение служебной команды, переданной после флагов запуска
+func runCommand(args []string) {
+	switch args[0] {
+	case "migrate-passwords":
+		migratePasswords()
+	case "purge-security-events":
+		purgeSecurityEvents()
+	case "admin":
+		runAdminCommand(args[1:])
+	case "import-animals":
+		importAnimals(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %s\n", args[0])
+		fmt.Fprintln(os.Stderr, "available commands: migrate-passwords, purge-security-events, admin, import-animals")
+		os.Exit(2)
+	}
+}
+
+// migratePasswords Хеширование паролей всех аккаунтов, которые хранятся в открытом виде
+func migratePasswords() {
+	migrated, err := newAccountService().MigratePasswords()
+	if err != nil {
+		log.Fatal("migrate passwords:", err)
+	}
+	log.Printf("Migrated %d passwords", migrated)
+}
+
+// purgeSecurityEvents Удаление записей журнала аутентификаций старше срока хранения
+func purgeSecurityEvents() {
+	securityEventRepo := repository.NewSecurityEventRepository(helpers.GetConnectionOrCreateAndGet())
+	auditService := audit.NewAuditService(securityEventRepo, audit.NewParamsFromConfig())
+
+	deleted, err := auditService.Purge()
+	if err != nil {
+		log.Fatal("purge security events:", err)
+	}
+	log.Printf("Deleted %d security events", deleted)
+}
+
+func newAccountService() service.Account {
+	accountRepo := repository.NewAccountRepository(helpers.GetConnectionOrCreateAndGet())
+	passwordService := password.NewPasswordService(password.NewParamsFromConfig())
+	accountCache := accountcache.NewAccountCacheService(accountcache.NewParamsFromConfig())
+	return service.NewAccountService(accountRepo, passwordService, accountCache)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"it-planet-task/helpers"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/importer"
+	"it-planet-task/internal/app/service/permission"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// importAnimals Массовый импорт животных из файла по тем же правилам, что и POST /animals/import.
+// Отчёт по строкам выводится в stdout в формате JSON
+func importAnimals(args []string) {
+	flags := flag.NewFlagSet("import-animals", flag.ExitOnError)
+	file := flags.String("file", "", "path to csv or ndjson file")
+	format := flags.String("format", "", "file format csv or ndjson, by file extension if empty")
+	mode := flags.String("mode", filter.AtomicImport, "import mode atomic or best-effort")
+	dryRun := flags.Bool("dry-run", false, "validate rows without creating animals")
+	organizationId := flags.Int("organization", 0, "organization id of imported animals, all organizations if empty")
+	_ = flags.Parse(args)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*file), ".")
+	}
+	params := &filter.AnimalImportParams{
+		Format: *format,
+		Mode:   *mode,
+		DryRun: *dryRun,
+		Tenant: &filter.TenantScope{Unrestricted: true},
+	}
+	if *organizationId != 0 {
+		params.Tenant = &filter.TenantScope{OrganizationId: organizationId}
+	}
+	if params.Format != importer.CSV && params.Format != importer.NDJSON {
+		log.Fatalf("import-animals: unknown format %q", params.Format)
+	}
+	if params.Mode != filter.AtomicImport && params.Mode != filter.BestEffortImport {
+		log.Fatalf("import-animals: unknown mode %q", params.Mode)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal("import-animals: ", err)
+	}
+	defer f.Close()
+
+	db := helpers.GetConnectionOrCreateAndGet()
+	animalImportService := service.NewAnimalImportService(
+		repository.NewAnimalRepository(db),
+		newAccountService(),
+		service.NewLocationService(repository.NewLocationRepository(db)),
+		service.NewAnimalTypeService(repository.NewAnimalTypeRepository(db)),
+		permission.NewPermissionService(permission.NewRolesFromConfig()),
+		service.NewAnimalImportParamsFromConfig(),
+	)
+
+	report, httpErr := animalImportService.Import(f, params)
+	if httpErr != nil {
+		log.Fatal("import-animals: ", httpErr.Err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(report)
+
+	log.Printf("Imported %d of %d animals, %d rows failed", report.Created, report.Total, report.Failed)
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"flag"
 	"github.com/gin-gonic/gin"
 	"it-planet-task/helpers"
 	"it-planet-task/internal/pkg/app"
+	"it-planet-task/internal/pkg/middleware"
 	"it-planet-task/pkg/config"
 	"log"
 	"os"
@@ -22,7 +24,13 @@ func Init() {
 
 func main() {
 	Init()
-	r := gin.Default()
+	if flag.NArg() > 0 {
+		runCommand(flag.Args())
+		return
+	}
+
+	r := gin.New()
+	r.Use(gin.Logger(), middleware.Recovery)
 
 	a := app.New(r)
 	srv := a.GetServer()
@@ -35,7 +43,7 @@ func main() {
 	}()
 
 	// graceful shutdown
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
@@ -3,45 +3,80 @@ package helpers
 import (
 	"gorm.io/gorm"
 	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/service/password"
+	"it-planet-task/pkg/config"
 	"log"
+	"os"
 )
 
 // GormMigrate Запуск миграций БД
 func GormMigrate(db *gorm.DB) {
 	err := db.AutoMigrate(&entity.AnimalType{}, &entity.Account{}, &entity.Animal{}, &entity.Location{},
-		&entity.AnimalLocation{}, &entity.Area{}, &entity.AreaPoint{})
+		&entity.AnimalLocation{}, &entity.Area{}, &entity.AreaPoint{}, &entity.RefreshToken{}, &entity.ApiKey{},
+		&entity.PasswordResetToken{}, &entity.Organization{}, &entity.Membership{}, &entity.AnimalShare{}, &entity.SecurityEvent{},
+		&entity.AnimalMeasurement{}, &entity.AnimalChip{}, &entity.Attachment{}, &entity.Observation{}, &entity.Tag{})
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
-// InitAccounts Инициализация начальных аккаунтов
+// BootstrapAccount Аккаунт, создаваемый при запуске, если аккаунта с таким email ещё нет.
+// Пароль задаётся явно или через переменную окружения PasswordEnv, чтобы не хранить его в конфигурации
+type BootstrapAccount struct {
+	FirstName   string
+	LastName    string
+	Email       string
+	Password    string
+	PasswordEnv string
+	Role        string
+}
+
+// InitAccounts Создание начальных аккаунтов из секции bootstrap конфигурационного файла.
+// Существующие аккаунты не изменяются, поэтому сменённые пароли не сбрасываются при перезапуске
 func InitAccounts(db *gorm.DB) {
-	adminAccount := &entity.Account{
-		FirstName: "adminFirstName",
-		LastName:  "adminLastName",
-		Email:     "admin@simbirsoft.com",
-		Password:  "qwerty123",
-		Role:      "ADMIN",
+	if !config.GetConfig().GetBool("bootstrap.enabled") {
+		return
 	}
 
-	chipperAccount := &entity.Account{
-		FirstName: "chipperFirstName",
-		LastName:  "chipperLastName",
-		Email:     "chipper@simbirsoft.com",
-		Password:  "qwerty123",
-		Role:      "CHIPPER",
+	var bootstrapAccounts []BootstrapAccount
+	err := config.GetConfig().UnmarshalKey("bootstrap.accounts", &bootstrapAccounts)
+	if err != nil {
+		log.Fatal("bootstrap accounts:", err)
 	}
 
-	userAccount := &entity.Account{
-		FirstName: "userFirstName",
-		LastName:  "userLastName",
-		Email:     "user@simbirsoft.com",
-		Password:  "qwerty123",
-		Role:      "USER",
-	}
+	passwordService := password.NewPasswordService(password.NewParamsFromConfig())
+	for _, bootstrapAccount := range bootstrapAccounts {
+		accountPassword := bootstrapAccount.Password
+		if bootstrapAccount.PasswordEnv != "" {
+			accountPassword = os.Getenv(bootstrapAccount.PasswordEnv)
+		}
+		if bootstrapAccount.Email == "" || accountPassword == "" {
+			log.Printf("Skipping bootstrap account %q without email or password", bootstrapAccount.Email)
+			continue
+		}
 
-	db.Save(adminAccount)
-	db.Save(chipperAccount)
-	db.Save(userAccount)
+		var count int64
+		db.Model(&entity.Account{}).Where("email = ?", bootstrapAccount.Email).Count(&count)
+		if count > 0 {
+			continue
+		}
+
+		hashedPassword, err := passwordService.Hash(accountPassword)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = db.Create(&entity.Account{
+			FirstName: bootstrapAccount.FirstName,
+			LastName:  bootstrapAccount.LastName,
+			Email:     bootstrapAccount.Email,
+			Password:  hashedPassword,
+			Role:      bootstrapAccount.Role,
+			Status:    entity.ActiveStatus,
+		}).Error
+		if err != nil {
+			log.Fatal("bootstrap accounts:", err)
+		}
+		log.Printf("Created bootstrap account %s", bootstrapAccount.Email)
+	}
 }
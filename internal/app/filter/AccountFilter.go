@@ -3,19 +3,36 @@ package filter
 import (
 	"fmt"
 	"gorm.io/gorm"
+	"it-planet-task/internal/app/model/entity"
 	"it-planet-task/internal/app/validator"
 	"it-planet-task/pkg/errorHandler"
 	"it-planet-task/pkg/paginator"
+	"net/http"
 	"net/url"
 	"strings"
 )
 
+// accountSortColumns Поля, по которым разрешена сортировка аккаунтов
+var accountSortColumns = map[string]string{
+	"id":        "id",
+	"firstName": "first_name",
+	"lastName":  "last_name",
+	"email":     "email",
+	"role":      "role",
+	"status":    "status",
+}
+
 // AccountFilterParams Фильтр поиска по аккаунтам
 type AccountFilterParams struct {
 	FirstName string
 	LastName  string
 	Email     string
+	Role      string
+	Status    string
+	// Sort поля сортировки в виде SQL выражений, например "last_name", "id DESC"
+	Sort []string
 
+	Tenant     *TenantScope
 	Pagination paginator.Pagination
 }
 
@@ -31,12 +48,31 @@ func NewAccountFilterParams(q url.Values) (*AccountFilterParams, *errorHandler.H
 	if q.Get("email") != "" {
 		params.Email = q.Get("email")
 	}
-	pagination, httpErr := validator.ValidateAndReturnPagination(q.Get("from"), q.Get("size"))
+	if q.Get("role") != "" {
+		params.Role = strings.ToUpper(q.Get("role"))
+	}
+	if q.Get("status") != "" {
+		status := strings.ToUpper(q.Get("status"))
+		if status != entity.ActiveStatus && status != entity.DisabledStatus {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("status must be %s or %s", entity.ActiveStatus, entity.DisabledStatus), http.StatusBadRequest)
+		}
+		params.Status = status
+	}
+
+	sort, httpErr := ValidateAndReturnSort(q.Get("sort"), accountSortColumns)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Sort = sort
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
 	if httpErr != nil {
 		return nil, httpErr
 	}
 
 	params.Pagination = *pagination
+	// общее количество аккаунтов возвращается всегда
+	params.Pagination.WithTotal = true
 
 	return params, nil
 }
@@ -45,6 +81,10 @@ func (a *AccountFilterParams) GetPagination() *paginator.Pagination {
 	return &a.Pagination
 }
 
+func (a *AccountFilterParams) GetSort() []string {
+	return a.Sort
+}
+
 // AccountFilter Фильтрация
 func AccountFilter(params *AccountFilterParams) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -63,6 +103,19 @@ func AccountFilter(params *AccountFilterParams) func(db *gorm.DB) *gorm.DB {
 				fmt.Sprintf("%%%s%%", strings.ToLower(params.Email)))
 		}
 
+		if params.Role != "" {
+			db = db.Where("role = ?", params.Role)
+		}
+
+		if params.Status != "" {
+			db = db.Where("status = ?", params.Status)
+		}
+
+		// в рамках организации ищутся только её участники
+		if params.Tenant != nil && params.Tenant.OrganizationId != nil {
+			db = db.Where("id IN (SELECT account_id FROM memberships WHERE organization_id = ?)", *params.Tenant.OrganizationId)
+		}
+
 		return db
 	}
 }
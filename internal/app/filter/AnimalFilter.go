@@ -1,15 +1,58 @@
 package filter
 
 import (
+	"fmt"
 	"gorm.io/gorm"
 	"it-planet-task/internal/app/validator"
 	"it-planet-task/internal/app/validator/AnimalValidator"
+	"it-planet-task/internal/app/validator/TagValidator"
 	"it-planet-task/pkg/errorHandler"
 	"it-planet-task/pkg/paginator"
+	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
+const (
+	// AnyAnimalTypesMatch Животное подходит, если у него есть хотя бы один из типов фильтра
+	AnyAnimalTypesMatch = "any"
+	// AllAnimalTypesMatch Животное подходит, если у него есть все типы фильтра
+	AllAnimalTypesMatch = "all"
+)
+
+const (
+	// AnyTagsMatch Животное подходит, если у него есть хотя бы одна из меток фильтра
+	AnyTagsMatch = "any"
+	// AllTagsMatch Животное подходит, если у него есть все метки фильтра
+	AllTagsMatch = "all"
+)
+
+// animalLastVisitSubquery Дата последнего посещения точки животным
+const animalLastVisitSubquery = "(SELECT MAX(al.date_time_of_visit_location_point) FROM animal_locations al WHERE al.animal_id = animals.id)"
+
+// animalCurrentLocationSubquery Текущая точка животного: последняя посещённая, а если посещений нет - точка чипирования
+const animalCurrentLocationSubquery = `COALESCE((
+	SELECT al.location_point_id FROM animal_locations al
+	WHERE al.animal_id = animals.id
+	ORDER BY al.date_time_of_visit_location_point DESC, al.id DESC
+	LIMIT 1
+), animals.chipping_location_id)`
+
+// animalSortColumns Поля, по которым разрешена сортировка животных
+var animalSortColumns = map[string]string{
+	"id":                 "id",
+	"chippingDateTime":   "chipping_date_time",
+	"chipperId":          "chipper_id",
+	"chippingLocationId": "chipping_location_id",
+	"weight":             "weight",
+	"height":             "height",
+	"length":             "length",
+	"gender":             "gender",
+	"lifeStatus":         "life_status",
+	"deathDateTime":      "death_date_time",
+}
+
 // AnimalFilterParams Фильтр поиска по животным
 type AnimalFilterParams struct {
 	StartDateTime      *time.Time
@@ -19,6 +62,30 @@ type AnimalFilterParams struct {
 	LifeStatus         string
 	Gender             string
 
+	AnimalTypeIds      []int
+	AnimalTypesMatch   string
+	MinWeight          *float64
+	MaxWeight          *float64
+	MinHeight          *float64
+	MaxHeight          *float64
+	MinLength          *float64
+	MaxLength          *float64
+	StartDeathDateTime *time.Time
+	EndDeathDateTime   *time.Time
+	VisitedLocationId  int
+	LastSeenAfter      *time.Time
+	LastSeenBefore     *time.Time
+	// AreaId Зона, внутри которой животное находится сейчас.
+	// Точки внутри зоны вычисляются по её многоугольнику и передаются в InsideLocationIds
+	AreaId            int
+	InsideLocationIds []int
+	// Tags нормализованные имена меток, TagsMatch - any или all
+	Tags      []string
+	TagsMatch string
+	// Sort поля сортировки в виде SQL выражений, например "weight DESC", "id"
+	Sort []string
+
+	Tenant     *TenantScope
 	Pagination paginator.Pagination
 }
 
@@ -26,6 +93,10 @@ func (a *AnimalFilterParams) GetPagination() *paginator.Pagination {
 	return &a.Pagination
 }
 
+func (a *AnimalFilterParams) GetSort() []string {
+	return a.Sort
+}
+
 // NewAnimalFilterParams Конструктор фильтра
 func NewAnimalFilterParams(q url.Values) (*AnimalFilterParams, *errorHandler.HttpErr) {
 	params := &AnimalFilterParams{}
@@ -54,11 +125,11 @@ func NewAnimalFilterParams(q url.Values) (*AnimalFilterParams, *errorHandler.Htt
 	}
 
 	if q.Get("chippingLocationId") != "" {
-		chipperLocationId, httpErr := validator.ValidateAndReturnId(q.Get("chipperLocationId"), "chipperLocationId")
+		chippingLocationId, httpErr := validator.ValidateAndReturnId(q.Get("chippingLocationId"), "chippingLocationId")
 		if httpErr != nil {
 			return nil, httpErr
 		}
-		params.ChippingLocationId = chipperLocationId
+		params.ChippingLocationId = chippingLocationId
 	}
 
 	if q.Get("lifeStatus") != "" {
@@ -77,7 +148,94 @@ func NewAnimalFilterParams(q url.Values) (*AnimalFilterParams, *errorHandler.Htt
 		params.Gender = q.Get("gender")
 	}
 
-	pagination, httpErr := validator.ValidateAndReturnPagination(q.Get("from"), q.Get("size"))
+	animalTypeIds, httpErr := validateAndReturnIds(q["animalTypeIds"], "animalTypeIds")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.AnimalTypeIds = animalTypeIds
+
+	params.AnimalTypesMatch = AnyAnimalTypesMatch
+	if q.Get("animalTypesMatch") != "" {
+		params.AnimalTypesMatch = strings.ToLower(q.Get("animalTypesMatch"))
+		if params.AnimalTypesMatch != AnyAnimalTypesMatch && params.AnimalTypesMatch != AllAnimalTypesMatch {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("animalTypesMatch must be %s or %s", AnyAnimalTypesMatch, AllAnimalTypesMatch), http.StatusBadRequest)
+		}
+	}
+
+	params.MinWeight, params.MaxWeight, httpErr = validateAndReturnRange(q.Get("minWeight"), q.Get("maxWeight"), "Weight")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.MinHeight, params.MaxHeight, httpErr = validateAndReturnRange(q.Get("minHeight"), q.Get("maxHeight"), "Height")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.MinLength, params.MaxLength, httpErr = validateAndReturnRange(q.Get("minLength"), q.Get("maxLength"), "Length")
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	if q.Get("startDeathDateTime") != "" {
+		params.StartDeathDateTime, httpErr = validator.ValidateAndReturnDateTime(q.Get("startDeathDateTime"), "startDeathDateTime")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+	}
+
+	if q.Get("endDeathDateTime") != "" {
+		params.EndDeathDateTime, httpErr = validator.ValidateAndReturnDateTime(q.Get("endDeathDateTime"), "endDeathDateTime")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+	}
+
+	if q.Get("visitedLocationId") != "" {
+		params.VisitedLocationId, httpErr = validator.ValidateAndReturnId(q.Get("visitedLocationId"), "visitedLocationId")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+	}
+
+	if q.Get("lastSeenAfter") != "" {
+		params.LastSeenAfter, httpErr = validator.ValidateAndReturnDateTime(q.Get("lastSeenAfter"), "lastSeenAfter")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+	}
+
+	if q.Get("lastSeenBefore") != "" {
+		params.LastSeenBefore, httpErr = validator.ValidateAndReturnDateTime(q.Get("lastSeenBefore"), "lastSeenBefore")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+	}
+
+	if q.Get("areaId") != "" {
+		params.AreaId, httpErr = validator.ValidateAndReturnId(q.Get("areaId"), "areaId")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+	}
+
+	params.Tags, httpErr = validateAndReturnTags(q["tags"])
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	params.TagsMatch = AnyTagsMatch
+	if q.Get("tagsMatch") != "" {
+		params.TagsMatch = strings.ToLower(q.Get("tagsMatch"))
+		if params.TagsMatch != AnyTagsMatch && params.TagsMatch != AllTagsMatch {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("tagsMatch must be %s or %s", AnyTagsMatch, AllTagsMatch), http.StatusBadRequest)
+		}
+	}
+
+	params.Sort, httpErr = ValidateAndReturnSort(q.Get("sort"), animalSortColumns)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
 	if httpErr != nil {
 		return nil, httpErr
 	}
@@ -113,6 +271,132 @@ func AnimalFilter(a *AnimalFilterParams) func(db *gorm.DB) *gorm.DB {
 			db = db.Where("gender = ?", a.Gender)
 		}
 
-		return db
+		if len(a.AnimalTypeIds) > 0 {
+			if a.AnimalTypesMatch == AllAnimalTypesMatch {
+				db = db.Where("animals.id IN (SELECT animal_id FROM animal_animal_type WHERE animal_type_id IN ? GROUP BY animal_id HAVING COUNT(DISTINCT animal_type_id) = ?)", a.AnimalTypeIds, len(a.AnimalTypeIds))
+			} else {
+				db = db.Where("animals.id IN (SELECT animal_id FROM animal_animal_type WHERE animal_type_id IN ?)", a.AnimalTypeIds)
+			}
+		}
+
+		if len(a.Tags) > 0 {
+			if a.TagsMatch == AllTagsMatch {
+				db = db.Where("animals.id IN (SELECT animal_tags.animal_id FROM animal_tags JOIN tags ON tags.id = animal_tags.tag_id WHERE tags.name IN ? GROUP BY animal_tags.animal_id HAVING COUNT(DISTINCT tags.name) = ?)", a.Tags, len(a.Tags))
+			} else {
+				db = db.Where("animals.id IN (SELECT animal_tags.animal_id FROM animal_tags JOIN tags ON tags.id = animal_tags.tag_id WHERE tags.name IN ?)", a.Tags)
+			}
+		}
+
+		if a.MinWeight != nil {
+			db = db.Where("weight >= ?", a.MinWeight)
+		}
+		if a.MaxWeight != nil {
+			db = db.Where("weight <= ?", a.MaxWeight)
+		}
+		if a.MinHeight != nil {
+			db = db.Where("height >= ?", a.MinHeight)
+		}
+		if a.MaxHeight != nil {
+			db = db.Where("height <= ?", a.MaxHeight)
+		}
+		if a.MinLength != nil {
+			db = db.Where("length >= ?", a.MinLength)
+		}
+		if a.MaxLength != nil {
+			db = db.Where("length <= ?", a.MaxLength)
+		}
+
+		if a.StartDeathDateTime != nil {
+			db = db.Where("death_date_time >= ?", a.StartDeathDateTime)
+		}
+		if a.EndDeathDateTime != nil {
+			db = db.Where("death_date_time <= ?", a.EndDeathDateTime)
+		}
+
+		if a.VisitedLocationId != 0 {
+			db = db.Where("EXISTS (SELECT 1 FROM animal_locations al WHERE al.animal_id = animals.id AND al.location_point_id = ?)", a.VisitedLocationId)
+		}
+
+		// Животное без посещений последний раз видели при чипировании
+		if a.LastSeenAfter != nil {
+			db = db.Where("COALESCE("+animalLastVisitSubquery+", animals.chipping_date_time) >= ?", a.LastSeenAfter)
+		}
+		if a.LastSeenBefore != nil {
+			db = db.Where("COALESCE("+animalLastVisitSubquery+", animals.chipping_date_time) <= ?", a.LastSeenBefore)
+		}
+
+		if a.AreaId != 0 {
+			if len(a.InsideLocationIds) == 0 {
+				db = db.Where("1 = 0")
+			} else {
+				db = db.Where(animalCurrentLocationSubquery+" IN ?", a.InsideLocationIds)
+			}
+		}
+
+		return AnimalTenantFilter(a.Tenant)(db)
+	}
+}
+
+// validateAndReturnIds Разбор списка идентификаторов. Идентификаторы передаются через запятую или повтором параметра
+func validateAndReturnIds(values []string, fieldName string) ([]int, *errorHandler.HttpErr) {
+	ids := make([]int, 0)
+	seen := make(map[int]bool)
+	for _, value := range values {
+		for _, idStr := range strings.Split(value, ",") {
+			if strings.TrimSpace(idStr) == "" {
+				continue
+			}
+			id, httpErr := validator.ValidateAndReturnId(strings.TrimSpace(idStr), fieldName)
+			if httpErr != nil {
+				return nil, httpErr
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, nil
+}
+
+// validateAndReturnTags Разбор списка меток. Метки передаются через запятую или повтором параметра
+func validateAndReturnTags(values []string) ([]string, *errorHandler.HttpErr) {
+	names := make([]string, 0)
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			if strings.TrimSpace(name) != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	return TagValidator.NormalizeTags(names)
+}
+
+// validateAndReturnRange Разбор диапазона значений minX и maxX. Границы не могут быть отрицательными, min не больше max
+func validateAndReturnRange(minStr, maxStr, fieldName string) (*float64, *float64, *errorHandler.HttpErr) {
+	var minValue, maxValue *float64
+	if minStr != "" {
+		value, httpErr := validator.ValidateAndReturnFloatField(minStr, "min"+fieldName, 64)
+		if httpErr != nil {
+			return nil, nil, httpErr
+		}
+		if value < 0 {
+			return nil, nil, errorHandler.NewHttpErr(fmt.Sprintf("min%s must be greater or equal to 0", fieldName), http.StatusBadRequest)
+		}
+		minValue = &value
+	}
+	if maxStr != "" {
+		value, httpErr := validator.ValidateAndReturnFloatField(maxStr, "max"+fieldName, 64)
+		if httpErr != nil {
+			return nil, nil, httpErr
+		}
+		if value < 0 {
+			return nil, nil, errorHandler.NewHttpErr(fmt.Sprintf("max%s must be greater or equal to 0", fieldName), http.StatusBadRequest)
+		}
+		maxValue = &value
+	}
+	if minValue != nil && maxValue != nil && *minValue > *maxValue {
+		return nil, nil, errorHandler.NewHttpErr(fmt.Sprintf("min%s must be less or equal to max%s", fieldName, fieldName), http.StatusBadRequest)
 	}
+	return minValue, maxValue, nil
 }
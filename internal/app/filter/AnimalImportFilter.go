@@ -0,0 +1,58 @@
+package filter
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/service/importer"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// AtomicImport животные создаются, только если все строки корректны, и в одной транзакции
+	AtomicImport = "atomic"
+	// BestEffortImport создаются корректные строки, ошибочные попадают в отчёт
+	BestEffortImport = "best-effort"
+)
+
+// AnimalImportParams Параметры массового импорта животных
+type AnimalImportParams struct {
+	Format string
+	Mode   string
+	// DryRun только проверка строк без создания животных
+	DryRun bool
+	// ImportedById аккаунт, от имени которого записываются начальные замеры
+	ImportedById *int
+	// Tenant организация, в которую импортируются животные и точки которой доступны
+	Tenant *TenantScope
+}
+
+// NewAnimalImportParams Разбор параметров format, mode и dryRun. Без format формат определяется по contentType
+func NewAnimalImportParams(q url.Values, contentType string) (*AnimalImportParams, *errorHandler.HttpErr) {
+	params := &AnimalImportParams{Format: q.Get("format"), Mode: q.Get("mode")}
+
+	if params.Format == "" {
+		params.Format = importer.FormatFromContentType(contentType)
+	}
+	if params.Format != importer.CSV && params.Format != importer.NDJSON {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("format must be in [%s, %s]", importer.CSV, importer.NDJSON), http.StatusBadRequest)
+	}
+
+	if params.Mode == "" {
+		params.Mode = AtomicImport
+	}
+	if params.Mode != AtomicImport && params.Mode != BestEffortImport {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("mode must be in [%s, %s]", AtomicImport, BestEffortImport), http.StatusBadRequest)
+	}
+
+	if q.Get("dryRun") != "" {
+		dryRun, err := strconv.ParseBool(q.Get("dryRun"))
+		if err != nil {
+			return nil, errorHandler.NewHttpErr("dryRun must be true or false", http.StatusBadRequest)
+		}
+		params.DryRun = dryRun
+	}
+
+	return params, nil
+}
@@ -9,9 +9,18 @@ import (
 	"time"
 )
 
+// animalLocationSortColumns Поля, по которым разрешена сортировка посещённых точек
+var animalLocationSortColumns = map[string]string{
+	"id":                           "id",
+	"dateTimeOfVisitLocationPoint": "date_time_of_visit_location_point",
+	"locationPointId":              "location_point_id",
+}
+
 type AnimalLocationFilterParams struct {
 	StartDateTime *time.Time
 	EndDateTime   *time.Time
+	// Sort поля сортировки в виде SQL выражений, например "date_time_of_visit_location_point DESC", "id"
+	Sort []string
 
 	Pagination paginator.Pagination
 }
@@ -20,6 +29,10 @@ func (a *AnimalLocationFilterParams) GetPagination() *paginator.Pagination {
 	return &a.Pagination
 }
 
+func (a *AnimalLocationFilterParams) GetSort() []string {
+	return a.Sort
+}
+
 func NewAnimalLocationFilterParams(q url.Values) (*AnimalLocationFilterParams, *errorHandler.HttpErr) {
 	params := &AnimalLocationFilterParams{}
 
@@ -39,7 +52,13 @@ func NewAnimalLocationFilterParams(q url.Values) (*AnimalLocationFilterParams, *
 		params.EndDateTime = endDateTime
 	}
 
-	pagination, httpErr := validator.ValidateAndReturnPagination(q.Get("from"), q.Get("size"))
+	sort, httpErr := ValidateAndReturnSort(q.Get("sort"), animalLocationSortColumns)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Sort = sort
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
 	if httpErr != nil {
 		return nil, httpErr
 	}
@@ -0,0 +1,104 @@
+package filter
+
+import (
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/internal/app/validator/AnimalMeasurementValidator"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// animalMeasurementSortColumns Поля, по которым разрешена сортировка замеров
+var animalMeasurementSortColumns = map[string]string{
+	"id":         "id",
+	"measuredAt": "measured_at",
+	"type":       "type",
+	"value":      "value",
+}
+
+// AnimalMeasurementFilterParams Фильтр истории замеров животного
+type AnimalMeasurementFilterParams struct {
+	Type          string
+	StartDateTime *time.Time
+	EndDateTime   *time.Time
+	// Sort поля сортировки в виде SQL выражений. По умолчанию замеры идут в порядке времени
+	Sort []string
+
+	Pagination paginator.Pagination
+}
+
+func (a *AnimalMeasurementFilterParams) GetPagination() *paginator.Pagination {
+	return &a.Pagination
+}
+
+func (a *AnimalMeasurementFilterParams) GetSort() []string {
+	return a.Sort
+}
+
+// NewAnimalMeasurementFilterParams Конструктор фильтра
+func NewAnimalMeasurementFilterParams(q url.Values) (*AnimalMeasurementFilterParams, *errorHandler.HttpErr) {
+	params := &AnimalMeasurementFilterParams{}
+	if q.Get("type") != "" {
+		httpErr := AnimalMeasurementValidator.ValidateMeasurementType(q.Get("type"))
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.Type = strings.ToUpper(q.Get("type"))
+	}
+
+	if q.Get("startDateTime") != "" {
+		startDateTime, httpErr := validator.ValidateAndReturnDateTime(q.Get("startDateTime"), "startDateTime")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.StartDateTime = startDateTime
+	}
+
+	if q.Get("endDateTime") != "" {
+		endDateTime, httpErr := validator.ValidateAndReturnDateTime(q.Get("endDateTime"), "endDateTime")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.EndDateTime = endDateTime
+	}
+
+	sort := q.Get("sort")
+	if sort == "" {
+		sort = "measuredAt"
+	}
+	orders, httpErr := ValidateAndReturnSort(sort, animalMeasurementSortColumns)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Sort = orders
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Pagination = *pagination
+
+	return params, nil
+}
+
+// AnimalMeasurementFilter Фильтрация
+func AnimalMeasurementFilter(a *AnimalMeasurementFilterParams) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if a.Type != "" {
+			db = db.Where("type = ?", a.Type)
+		}
+
+		if a.StartDateTime != nil {
+			db = db.Where("measured_at >= ?", a.StartDateTime)
+		}
+
+		if a.EndDateTime != nil {
+			db = db.Where("measured_at <= ?", a.EndDateTime)
+		}
+
+		return db
+	}
+}
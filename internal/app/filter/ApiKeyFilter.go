@@ -0,0 +1,56 @@
+package filter
+
+import (
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/url"
+)
+
+// ApiKeyFilterParams Фильтр поиска по API ключам
+type ApiKeyFilterParams struct {
+	AccountId int
+
+	Pagination paginator.Pagination
+}
+
+func (a *ApiKeyFilterParams) GetPagination() *paginator.Pagination {
+	return &a.Pagination
+}
+
+func (a *ApiKeyFilterParams) GetSort() []string {
+	return nil
+}
+
+// NewApiKeyFilterParams Конструктор фильтра
+func NewApiKeyFilterParams(q url.Values) (*ApiKeyFilterParams, *errorHandler.HttpErr) {
+	params := &ApiKeyFilterParams{}
+	if q.Get("accountId") != "" {
+		accountId, httpErr := validator.ValidateAndReturnId(q.Get("accountId"), "accountId")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.AccountId = accountId
+	}
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	params.Pagination = *pagination
+
+	return params, nil
+}
+
+// ApiKeyFilter Фильтрация
+func ApiKeyFilter(params *ApiKeyFilterParams) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if params.AccountId != 0 {
+			db = db.Where("account_id = ?", params.AccountId)
+		}
+
+		return db
+	}
+}
@@ -12,6 +12,7 @@ import (
 type AreaAnalyticsFilterParams struct {
 	StartDateTime *time.Time
 	EndDateTime   *time.Time
+	Tenant        *TenantScope
 	Pagination    paginator.Pagination
 }
 
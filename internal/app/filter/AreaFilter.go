@@ -7,7 +7,17 @@ import (
 	"net/url"
 )
 
+// areaSortColumns Поля, по которым разрешена сортировка зон
+var areaSortColumns = map[string]string{
+	"id":   "id",
+	"name": "name",
+}
+
 type AreaFilterParams struct {
+	// Sort поля сортировки в виде SQL выражений, например "name DESC", "id"
+	Sort []string
+
+	Tenant     *TenantScope
 	Pagination paginator.Pagination
 }
 
@@ -15,9 +25,19 @@ func (a *AreaFilterParams) GetPagination() *paginator.Pagination {
 	return &a.Pagination
 }
 
+func (a *AreaFilterParams) GetSort() []string {
+	return a.Sort
+}
+
 func NewAreaFilterParams(q url.Values) (*AreaFilterParams, *errorHandler.HttpErr) {
 	params := &AreaFilterParams{}
-	pagination, httpErr := validator.ValidateAndReturnPagination(q.Get("from"), q.Get("size"))
+	sort, httpErr := ValidateAndReturnSort(q.Get("sort"), areaSortColumns)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Sort = sort
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
 	if httpErr != nil {
 		return nil, httpErr
 	}
@@ -0,0 +1,55 @@
+package filter
+
+import (
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/url"
+)
+
+// attachmentSortColumns Поля, по которым разрешена сортировка вложений
+var attachmentSortColumns = map[string]string{
+	"id":        "id",
+	"createdAt": "created_at",
+	"fileName":  "file_name",
+	"size":      "size",
+}
+
+// AttachmentFilterParams Параметры списка вложений животного
+type AttachmentFilterParams struct {
+	// Sort поля сортировки в виде SQL выражений. По умолчанию вложения идут в порядке загрузки
+	Sort []string
+
+	Pagination paginator.Pagination
+}
+
+func (a *AttachmentFilterParams) GetPagination() *paginator.Pagination {
+	return &a.Pagination
+}
+
+func (a *AttachmentFilterParams) GetSort() []string {
+	return a.Sort
+}
+
+// NewAttachmentFilterParams Конструктор фильтра
+func NewAttachmentFilterParams(q url.Values) (*AttachmentFilterParams, *errorHandler.HttpErr) {
+	params := &AttachmentFilterParams{}
+
+	sort := q.Get("sort")
+	if sort == "" {
+		sort = "createdAt"
+	}
+	orders, httpErr := ValidateAndReturnSort(sort, attachmentSortColumns)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Sort = orders
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Pagination = *pagination
+
+	return params, nil
+}
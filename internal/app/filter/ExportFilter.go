@@ -0,0 +1,22 @@
+package filter
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/service/exporter"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ValidateAndReturnExportFormat Разбор параметра format выгрузки, по умолчанию CSV
+func ValidateAndReturnExportFormat(q url.Values) (string, *errorHandler.HttpErr) {
+	format := strings.ToLower(q.Get("format"))
+	switch format {
+	case "":
+		return exporter.CSV, nil
+	case exporter.CSV, exporter.NDJSON, exporter.GeoJSON:
+		return format, nil
+	}
+	return "", errorHandler.NewHttpErr(fmt.Sprintf("format must be in [%s, %s, %s]", exporter.CSV, exporter.NDJSON, exporter.GeoJSON), http.StatusBadRequest)
+}
@@ -0,0 +1,36 @@
+package filter
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"net/url"
+)
+
+const (
+	DefaultLineageDepth = 3
+	MaxLineageDepth     = 10
+)
+
+// LineageParams Параметры построения родословной
+type LineageParams struct {
+	// Depth количество поколений от животного
+	Depth  int
+	Tenant *TenantScope
+}
+
+func NewLineageParams(q url.Values) (*LineageParams, *errorHandler.HttpErr) {
+	params := &LineageParams{Depth: DefaultLineageDepth}
+	if q.Get("depth") != "" {
+		depth, httpErr := validator.ValidateAndReturnIntField(q.Get("depth"), "depth")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		if depth < 1 || depth > MaxLineageDepth {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("depth must be between 1 and %d", MaxLineageDepth), http.StatusBadRequest)
+		}
+		params.Depth = depth
+	}
+	return params, nil
+}
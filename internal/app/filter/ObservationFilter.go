@@ -0,0 +1,156 @@
+package filter
+
+import (
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/internal/app/validator/ObservationValidator"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// observationSortColumns Поля, по которым разрешена сортировка наблюдений
+var observationSortColumns = map[string]string{
+	"id":         "id",
+	"observedAt": "observed_at",
+	"createdAt":  "created_at",
+}
+
+// ObservationFilterParams Фильтр наблюдений. Query ищется по тексту наблюдений полнотекстовым поиском
+type ObservationFilterParams struct {
+	AnimalId               *int
+	VisitedLocationPointId *int
+	AuthorId               *int
+	Query                  string
+	// TextSearchConfigs конфигурации, по которым разбирается Query. По умолчанию русская и английская
+	TextSearchConfigs []string
+	StartDateTime     *time.Time
+	EndDateTime       *time.Time
+	// Sort поля сортировки в виде SQL выражений. По умолчанию сначала идут последние наблюдения
+	Sort []string
+	// Tenant доступные организации. Для списка наблюдений одного животного доступ проверяется по животному
+	Tenant *TenantScope
+
+	Pagination paginator.Pagination
+}
+
+func (o *ObservationFilterParams) GetPagination() *paginator.Pagination {
+	return &o.Pagination
+}
+
+func (o *ObservationFilterParams) GetSort() []string {
+	return o.Sort
+}
+
+// NewObservationFilterParams Конструктор фильтра
+func NewObservationFilterParams(q url.Values) (*ObservationFilterParams, *errorHandler.HttpErr) {
+	params := &ObservationFilterParams{
+		Query:             strings.TrimSpace(q.Get("q")),
+		TextSearchConfigs: []string{entity.RussianTextSearch, entity.EnglishTextSearch},
+	}
+
+	if q.Get("language") != "" {
+		textSearchConfig, httpErr := ObservationValidator.ValidateAndReturnTextSearchConfig(q.Get("language"))
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.TextSearchConfigs = []string{textSearchConfig}
+	}
+
+	for field, dest := range map[string]**int{
+		"animalId":               &params.AnimalId,
+		"visitedLocationPointId": &params.VisitedLocationPointId,
+		"authorId":               &params.AuthorId,
+	} {
+		if q.Get(field) == "" {
+			continue
+		}
+		id, httpErr := validator.ValidateAndReturnId(q.Get(field), field)
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		*dest = &id
+	}
+
+	if q.Get("startDateTime") != "" {
+		startDateTime, httpErr := validator.ValidateAndReturnDateTime(q.Get("startDateTime"), "startDateTime")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.StartDateTime = startDateTime
+	}
+
+	if q.Get("endDateTime") != "" {
+		endDateTime, httpErr := validator.ValidateAndReturnDateTime(q.Get("endDateTime"), "endDateTime")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.EndDateTime = endDateTime
+	}
+
+	sort := q.Get("sort")
+	if sort == "" {
+		sort = "-observedAt"
+	}
+	orders, httpErr := ValidateAndReturnSort(sort, observationSortColumns)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Sort = orders
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	params.Pagination = *pagination
+
+	return params, nil
+}
+
+// ObservationFilter Фильтрация
+func ObservationFilter(o *ObservationFilterParams) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if o.AnimalId != nil {
+			db = db.Where("animal_id = ?", *o.AnimalId)
+		}
+
+		if o.VisitedLocationPointId != nil {
+			db = db.Where("animal_location_id = ?", *o.VisitedLocationPointId)
+		}
+
+		if o.AuthorId != nil {
+			db = db.Where("author_id = ?", *o.AuthorId)
+		}
+
+		if o.Query != "" {
+			queries := make([]string, 0, len(o.TextSearchConfigs))
+			args := make([]interface{}, 0, 2*len(o.TextSearchConfigs))
+			for _, textSearchConfig := range o.TextSearchConfigs {
+				queries = append(queries, "websearch_to_tsquery(?::regconfig, ?)")
+				args = append(args, textSearchConfig, o.Query)
+			}
+			db = db.Where("search_vector @@ ("+strings.Join(queries, " || ")+")", args...)
+		}
+
+		if o.StartDateTime != nil {
+			db = db.Where("observed_at >= ?", o.StartDateTime)
+		}
+
+		if o.EndDateTime != nil {
+			db = db.Where("observed_at <= ?", o.EndDateTime)
+		}
+
+		if o.Tenant != nil {
+			animals := db.Session(&gorm.Session{NewDB: true}).
+				Model(&entity.Animal{}).
+				Select("animals.id").
+				Scopes(AnimalTenantFilter(o.Tenant))
+			db = db.Where("animal_id IN (?)", animals)
+		}
+
+		return db
+	}
+}
@@ -0,0 +1,61 @@
+package filter
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/url"
+	"strings"
+)
+
+// OrganizationFilterParams Фильтр поиска по организациям
+type OrganizationFilterParams struct {
+	Name string
+	// MemberId поиск только организаций, участником которых является аккаунт
+	MemberId int
+
+	Pagination paginator.Pagination
+}
+
+func (o *OrganizationFilterParams) GetPagination() *paginator.Pagination {
+	return &o.Pagination
+}
+
+func (o *OrganizationFilterParams) GetSort() []string {
+	return nil
+}
+
+// NewOrganizationFilterParams Конструктор фильтра
+func NewOrganizationFilterParams(q url.Values) (*OrganizationFilterParams, *errorHandler.HttpErr) {
+	params := &OrganizationFilterParams{}
+	if q.Get("name") != "" {
+		params.Name = q.Get("name")
+	}
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	params.Pagination = *pagination
+
+	return params, nil
+}
+
+// OrganizationFilter Фильтрация
+func OrganizationFilter(params *OrganizationFilterParams) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if params.Name != "" {
+			db = db.Where("LOWER(name) LIKE ?",
+				fmt.Sprintf("%%%s%%", strings.ToLower(params.Name)))
+		}
+
+		if params.MemberId != 0 {
+			db = db.Where("id IN (SELECT organization_id FROM memberships WHERE account_id = ?)", params.MemberId)
+		}
+
+		return db
+	}
+}
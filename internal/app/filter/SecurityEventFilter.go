@@ -0,0 +1,108 @@
+package filter
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SecurityEventFilterParams Фильтр журнала аутентификаций
+type SecurityEventFilterParams struct {
+	AccountId     int
+	Type          string
+	Method        string
+	StartDateTime *time.Time
+	EndDateTime   *time.Time
+
+	Pagination paginator.Pagination
+}
+
+func (s *SecurityEventFilterParams) GetPagination() *paginator.Pagination {
+	return &s.Pagination
+}
+
+// GetSort Журнал выдаётся от новых событий к старым
+func (s *SecurityEventFilterParams) GetSort() []string {
+	return []string{"created_at DESC", "id DESC"}
+}
+
+// NewSecurityEventFilterParams Конструктор фильтра
+func NewSecurityEventFilterParams(q url.Values) (*SecurityEventFilterParams, *errorHandler.HttpErr) {
+	params := &SecurityEventFilterParams{}
+	if q.Get("type") != "" {
+		eventType := strings.ToUpper(q.Get("type"))
+		if eventType != entity.AuthSuccessEvent && eventType != entity.AuthFailureEvent {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("type must be %s or %s", entity.AuthSuccessEvent, entity.AuthFailureEvent), http.StatusBadRequest)
+		}
+		params.Type = eventType
+	}
+
+	if q.Get("method") != "" {
+		method := strings.ToUpper(q.Get("method"))
+		switch method {
+		case entity.BasicAuthMethod, entity.BearerAuthMethod, entity.ApiKeyAuthMethod, entity.LoginAuthMethod, entity.OidcAuthMethod:
+		default:
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Unknown authentication method %s", q.Get("method")), http.StatusBadRequest)
+		}
+		params.Method = method
+	}
+
+	if q.Get("startDateTime") != "" {
+		startDateTime, httpErr := validator.ValidateAndReturnDateTime(q.Get("startDateTime"), "startDateTime")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.StartDateTime = startDateTime
+	}
+
+	if q.Get("endDateTime") != "" {
+		endDateTime, httpErr := validator.ValidateAndReturnDateTime(q.Get("endDateTime"), "endDateTime")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		params.EndDateTime = endDateTime
+	}
+
+	pagination, httpErr := validator.ValidateAndReturnPage(q)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	params.Pagination = *pagination
+
+	return params, nil
+}
+
+// SecurityEventFilter Фильтрация
+func SecurityEventFilter(params *SecurityEventFilterParams) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if params.AccountId != 0 {
+			db = db.Where("account_id = ?", params.AccountId)
+		}
+
+		if params.Type != "" {
+			db = db.Where("type = ?", params.Type)
+		}
+
+		if params.Method != "" {
+			db = db.Where("method = ?", params.Method)
+		}
+
+		if params.StartDateTime != nil {
+			db = db.Where("created_at >= ?", params.StartDateTime)
+		}
+
+		if params.EndDateTime != nil {
+			db = db.Where("created_at <= ?", params.EndDateTime)
+		}
+
+		return db
+	}
+}
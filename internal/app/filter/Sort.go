@@ -0,0 +1,46 @@
+package filter
+
+import (
+	"fmt"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"strings"
+)
+
+// ValidateAndReturnSort Разбор параметра сортировки вида "lastName,-id".
+// Минус перед полем задаёт сортировку по убыванию. columns сопоставляет полям запроса столбцы таблицы,
+// сортировка по другим полям запрещена. Для стабильного порядка страниц в конец добавляется сортировка по id
+func ValidateAndReturnSort(sort string, columns map[string]string) ([]string, *errorHandler.HttpErr) {
+	orders := make([]string, 0)
+	hasId := false
+
+	if sort != "" {
+		seen := make(map[string]bool)
+		for _, field := range strings.Split(sort, ",") {
+			field = strings.TrimSpace(field)
+			direction := ""
+			if strings.HasPrefix(field, "-") {
+				field = field[1:]
+				direction = " DESC"
+			}
+
+			column, ok := columns[field]
+			if !ok {
+				return nil, errorHandler.NewHttpErr(fmt.Sprintf("Sorting by %q is not supported", field), http.StatusBadRequest)
+			}
+			if seen[column] {
+				return nil, errorHandler.NewHttpErr(fmt.Sprintf("Field %q is used in sort more than once", field), http.StatusBadRequest)
+			}
+			seen[column] = true
+			hasId = hasId || column == "id"
+
+			orders = append(orders, column+direction)
+		}
+	}
+
+	if !hasId {
+		orders = append(orders, "id")
+	}
+
+	return orders, nil
+}
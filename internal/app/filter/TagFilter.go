@@ -0,0 +1,58 @@
+package filter
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultTagSuggestions = 10
+	maxTagSuggestions     = 100
+)
+
+// TagFilterParams Фильтр подсказок меток
+type TagFilterParams struct {
+	// Prefix начало имени метки
+	Prefix string
+	Size   int
+
+	Tenant *TenantScope
+}
+
+// NewTagFilterParams Конструктор фильтра. Начало имени передаётся в параметре q, число подсказок - в size
+func NewTagFilterParams(q url.Values) (*TagFilterParams, *errorHandler.HttpErr) {
+	params := &TagFilterParams{
+		Prefix: strings.ToLower(strings.Join(strings.Fields(q.Get("q")), " ")),
+		Size:   defaultTagSuggestions,
+	}
+
+	if q.Get("size") != "" {
+		size, httpErr := validator.ValidateAndReturnIntField(q.Get("size"), "size")
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		if size <= 0 || size > maxTagSuggestions {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("size must be between 1 and %d", maxTagSuggestions), http.StatusBadRequest)
+		}
+		params.Size = size
+	}
+
+	return params, nil
+}
+
+// TagFilter Фильтрация меток по началу имени и доступным организациям
+func TagFilter(params *TagFilterParams) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if params.Prefix != "" {
+			escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(params.Prefix)
+			db = db.Where("tags.name LIKE ?", escaped+"%")
+		}
+
+		return TenantFilter("tags", params.Tenant)(db)
+	}
+}
@@ -0,0 +1,62 @@
+package filter
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// TenantScope Организация, в рамках которой выполняется запрос.
+// Данные без организации находятся в общем пространстве и доступны всем
+type TenantScope struct {
+	// OrganizationId активная организация. В неё записываются создаваемые данные
+	OrganizationId *int
+	// Unrestricted доступ к данным всех организаций
+	Unrestricted bool
+}
+
+// CanAccess Проверка, что данные организации organizationId доступны в рамках запроса
+func (t *TenantScope) CanAccess(organizationId *int) bool {
+	if t == nil || t.Unrestricted || organizationId == nil {
+		return true
+	}
+	return t.OrganizationId != nil && *t.OrganizationId == *organizationId
+}
+
+// CanWrite Проверка, что данные организации organizationId можно изменять в рамках запроса.
+// В активной организации права определяются ролью участника, поэтому общие данные без организации доступны только на чтение
+func (t *TenantScope) CanWrite(organizationId *int) bool {
+	if t == nil || t.Unrestricted {
+		return true
+	}
+	if organizationId == nil {
+		return t.OrganizationId == nil
+	}
+	return t.OrganizationId != nil && *t.OrganizationId == *organizationId
+}
+
+// TenantFilter Фильтрация таблицы table по доступным организациям
+func TenantFilter(table string, scope *TenantScope) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope == nil || scope.Unrestricted {
+			return db
+		}
+
+		if scope.OrganizationId == nil {
+			return db.Where(fmt.Sprintf("%s.organization_id IS NULL", table))
+		}
+		return db.Where(fmt.Sprintf("(%s.organization_id IS NULL OR %s.organization_id = ?)", table, table), *scope.OrganizationId)
+	}
+}
+
+// AnimalTenantFilter Фильтрация животных по доступным организациям с учётом животных, которыми поделились с активной организацией
+func AnimalTenantFilter(scope *TenantScope) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if scope == nil || scope.Unrestricted || scope.OrganizationId == nil {
+			return TenantFilter("animals", scope)(db)
+		}
+
+		return db.Where("(animals.organization_id IS NULL OR animals.organization_id = ? OR "+
+			"animals.id IN (SELECT animal_id FROM animal_shares WHERE organization_id = ?))",
+			*scope.OrganizationId, *scope.OrganizationId)
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
 	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/permission"
 	"it-planet-task/internal/app/validator"
 	"it-planet-task/internal/app/validator/AccountValidator"
 	"net/http"
@@ -13,12 +17,13 @@ import (
 
 // AccountHandler Обработчик запросов для сущности "Аккаунт"
 type AccountHandler struct {
-	accountService service.Account
-	animalService  service.Animal
+	accountService    service.Account
+	animalService     service.Animal
+	permissionService permission.Permission
 }
 
-func NewAccountHandler(accountService service.Account, animalService service.Animal) *AccountHandler {
-	return &AccountHandler{accountService: accountService, animalService: animalService}
+func NewAccountHandler(accountService service.Account, animalService service.Animal, permissionService permission.Permission) *AccountHandler {
+	return &AccountHandler{accountService: accountService, animalService: animalService, permissionService: permissionService}
 }
 
 func (a *AccountHandler) Get(c *gin.Context) {
@@ -30,7 +35,7 @@ func (a *AccountHandler) Get(c *gin.Context) {
 
 	authorizedAccountAny, _ := c.Get("account")
 	authorizedAccount := authorizedAccountAny.(*entity.Account)
-	if (authorizedAccount.Role == entity.UserRole || authorizedAccount.Role == entity.ChipperRole) && (id != authorizedAccount.Id) {
+	if !a.permissionService.HasPermission(authorizedAccount.Role, permission.AccountsReadAny) && (id != authorizedAccount.Id) {
 		c.AbortWithStatusJSON(http.StatusForbidden, "Cant get another's account")
 		return
 	}
@@ -41,7 +46,7 @@ func (a *AccountHandler) Get(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, account)
+	c.JSON(http.StatusOK, mapper.RedactAccountResponse(account, viewer(c, a.permissionService)))
 }
 
 func (a *AccountHandler) Search(c *gin.Context) {
@@ -51,12 +56,14 @@ func (a *AccountHandler) Search(c *gin.Context) {
 		return
 	}
 
-	accounts, err := a.accountService.Search(params)
+	params.Tenant = tenantScope(c)
+	accounts, page, err := a.accountService.Search(params)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
 		return
 	}
-	c.JSON(http.StatusOK, accounts)
+	setPageHeaders(c, page)
+	c.JSON(http.StatusOK, mapper.RedactAccountResponses(accounts, viewer(c, a.permissionService)))
 }
 
 func (a *AccountHandler) Update(c *gin.Context) {
@@ -67,7 +74,7 @@ func (a *AccountHandler) Update(c *gin.Context) {
 	}
 	authorizedAccountAny, _ := c.Get("account")
 	authorizedAccount := authorizedAccountAny.(*entity.Account)
-	if (authorizedAccount.Role == entity.UserRole || authorizedAccount.Role == entity.ChipperRole) && (id != authorizedAccount.Id) {
+	if !a.permissionService.HasPermission(authorizedAccount.Role, permission.AccountsUpdateAny) && (id != authorizedAccount.Id) {
 		c.AbortWithStatusJSON(http.StatusForbidden, "Cant edit another's account")
 		return
 	}
@@ -79,7 +86,7 @@ func (a *AccountHandler) Update(c *gin.Context) {
 		return
 	}
 
-	httpErr = AccountValidator.ValidateAccount(newAccount)
+	httpErr = AccountValidator.ValidateAccount(newAccount, a.permissionService.Roles())
 	if httpErr != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, httpErr.Err.Error())
 		return
@@ -111,18 +118,40 @@ func (a *AccountHandler) Delete(c *gin.Context) {
 	}
 	authorizedAccountAny, _ := c.Get("account")
 	authorizedAccount := authorizedAccountAny.(*entity.Account)
-	if (authorizedAccount.Role == entity.UserRole || authorizedAccount.Role == entity.ChipperRole) && (id != authorizedAccount.Id) {
+	if !a.permissionService.HasPermission(authorizedAccount.Role, permission.AccountsDeleteAny) && (id != authorizedAccount.Id) {
 		c.AbortWithStatusJSON(http.StatusForbidden, "Cant delete another's account")
 		return
 	}
 
-	animals, _ := a.animalService.GetAnimalsByAccountId(id)
-	if len(*animals) != 0 {
-		c.AbortWithStatusJSON(http.StatusBadRequest, "Account has animals attached")
+	_, httpErr = a.accountService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	err := a.accountService.Disable(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (a *AccountHandler) Activate(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	_, httpErr = a.accountService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
 
-	err := a.accountService.Delete(id)
+	err := a.accountService.Activate(id)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
 		return
@@ -131,6 +160,61 @@ func (a *AccountHandler) Delete(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+func (a *AccountHandler) ReassignAnimals(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	reassignmentInput := &input.AnimalReassignment{}
+	err := c.BindJSON(&reassignmentInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr = AccountValidator.ValidateAnimalReassignmentInput(reassignmentInput, id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	_, httpErr = a.accountService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	chipper, httpErr := a.accountService.Get(*reassignmentInput.ChipperId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if chipper.Status == entity.DisabledStatus {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Chipper account is disabled")
+		return
+	}
+	if !a.permissionService.HasPermission(chipper.Role, permission.AnimalsChip) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant set chipper without permission "+permission.AnimalsChip)
+		return
+	}
+
+	disable := reassignmentInput.DisableAccount != nil && *reassignmentInput.DisableAccount
+	reassigned, err := a.accountService.ReassignAnimals(id, chipper.Id, disable)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, &response.AnimalReassignment{
+		FromChipperId:   id,
+		ToChipperId:     chipper.Id,
+		AnimalsCount:    reassigned,
+		AccountDisabled: disable,
+	})
+}
+
 func (a *AccountHandler) Create(c *gin.Context) {
 	newAccount := &entity.Account{}
 	err := c.BindJSON(&newAccount)
@@ -139,7 +223,7 @@ func (a *AccountHandler) Create(c *gin.Context) {
 		return
 	}
 
-	httpErr := AccountValidator.ValidateAccount(newAccount)
+	httpErr := AccountValidator.ValidateAccount(newAccount, a.permissionService.Roles())
 	if httpErr != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, httpErr.Err.Error())
 		return
@@ -7,10 +7,15 @@ import (
 	"it-planet-task/internal/app/mapper"
 	"it-planet-task/internal/app/model/entity"
 	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/chip"
+	"it-planet-task/internal/app/service/permission"
 	"it-planet-task/internal/app/validator"
 	"it-planet-task/internal/app/validator/AnimalValidator"
+	"it-planet-task/pkg/errorHandler"
 	"net/http"
+	"time"
 )
 
 // AnimalHandler Обработчик запросов для сущности "Животное"
@@ -20,10 +25,15 @@ type AnimalHandler struct {
 	accountService        service.Account
 	locationService       service.Location
 	animalLocationService service.AnimalLocation
+	areaService           service.Area
+	organizationService   service.Organization
+	measurementService    service.AnimalMeasurement
+	attachmentService     service.Attachment
+	permissionService     permission.Permission
 }
 
-func NewAnimalHandler(animalService service.Animal, animalTypeService service.AnimalType, accountService service.Account, locationService service.Location, animalLocationService service.AnimalLocation) *AnimalHandler {
-	return &AnimalHandler{animalService: animalService, animalTypeService: animalTypeService, accountService: accountService, locationService: locationService, animalLocationService: animalLocationService}
+func NewAnimalHandler(animalService service.Animal, animalTypeService service.AnimalType, accountService service.Account, locationService service.Location, animalLocationService service.AnimalLocation, areaService service.Area, organizationService service.Organization, measurementService service.AnimalMeasurement, attachmentService service.Attachment, permissionService permission.Permission) *AnimalHandler {
+	return &AnimalHandler{animalService: animalService, animalTypeService: animalTypeService, accountService: accountService, locationService: locationService, animalLocationService: animalLocationService, areaService: areaService, organizationService: organizationService, measurementService: measurementService, attachmentService: attachmentService, permissionService: permissionService}
 }
 
 func (a *AnimalHandler) Get(c *gin.Context) {
@@ -38,6 +48,9 @@ func (a *AnimalHandler) Get(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animal, false) {
+		return
+	}
 	c.JSON(http.StatusOK, animal)
 }
 
@@ -47,11 +60,17 @@ func (a *AnimalHandler) Search(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
-	animals, err := a.animalService.Search(params)
+	params.Tenant = tenantScope(c)
+	if !setInsideLocationIds(c, a.areaService, params) {
+		return
+	}
+
+	animals, page, err := a.animalService.Search(params)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
 		return
 	}
+	setPageHeaders(c, page)
 
 	c.JSON(http.StatusOK, animals)
 }
@@ -70,6 +89,17 @@ func (a *AnimalHandler) Create(c *gin.Context) {
 		return
 	}
 	newAnimal := mapper.AnimalInputToAnimal(animalInput)
+	newAnimal.OrganizationId = tenantScope(c).OrganizationId
+
+	parents := &input.AnimalParents{MotherId: animalInput.MotherId, FatherId: animalInput.FatherId, BirthDateTime: animalInput.BirthDateTime}
+	if !a.checkParents(c, 0, parents, time.Now()) {
+		return
+	}
+
+	if newAnimal.ChipCode != nil && a.animalService.IsChipRegistered(*newAnimal.ChipCode) {
+		c.AbortWithStatusJSON(http.StatusConflict, fmt.Sprintf("Chip %s is already registered", *newAnimal.ChipCode))
+		return
+	}
 
 	chipper, httpErr := a.accountService.Get(newAnimal.ChipperId)
 	if httpErr != nil {
@@ -77,16 +107,24 @@ func (a *AnimalHandler) Create(c *gin.Context) {
 		return
 	}
 
-	if chipper.Role != entity.ChipperRole && chipper.Role != entity.AdminRole {
-		c.AbortWithStatusJSON(http.StatusForbidden, "Cant set chipper without role Chipper or Admin")
+	if chipper.Status == entity.DisabledStatus {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Chipper account is disabled")
 		return
 	}
 
-	_, httpErr = a.locationService.Get(newAnimal.ChippingLocationId)
+	if !a.permissionService.HasPermission(chipper.Role, permission.AnimalsChip) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant set chipper without permission "+permission.AnimalsChip)
+		return
+	}
+
+	chippingLocation, httpErr := a.locationService.Get(newAnimal.ChippingLocationId)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkLocationAccess(c, chippingLocation, false) {
+		return
+	}
 
 	animalTypes, err := a.animalTypeService.GetByIds(&animalInput.AnimalTypeIds)
 	if err != nil {
@@ -104,6 +142,12 @@ func (a *AnimalHandler) Create(c *gin.Context) {
 		return
 	}
 
+	httpErr = a.measurementService.RecordChanges(animal, nil, authorizedAccountId(c), &animal.ChippingLocationId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
 	c.JSON(http.StatusCreated, animal)
 }
 
@@ -126,6 +170,9 @@ func (a *AnimalHandler) Update(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, oldAnimal, true) {
+		return
+	}
 
 	httpErr = AnimalValidator.ValidateAnimalUpdateInput(animalInput, oldAnimal)
 	if httpErr != nil {
@@ -145,6 +192,17 @@ func (a *AnimalHandler) Update(c *gin.Context) {
 		}
 	}
 
+	// пол и смерть родителя не должны нарушать проверки, пройденные при указании его потомков
+	if *animalInput.Gender != oldAnimal.Gender && a.animalService.HasOffspring(id) {
+		c.AbortWithStatusJSON(http.StatusConflict, "Cant change gender of animal with offspring")
+		return
+	}
+	if *animalInput.LifeStatus == entity.Dead && oldAnimal.LifeStatus == entity.Alive &&
+		a.animalService.HasOffspringBornAfter(id, time.Now()) {
+		c.AbortWithStatusJSON(http.StatusConflict, "Animal cant die before birth of its offspring")
+		return
+	}
+
 	animalInput.AnimalTypeIds = oldAnimal.AnimalTypesId
 	newAnimal := mapper.AnimalInputToAnimal(animalInput)
 
@@ -154,16 +212,24 @@ func (a *AnimalHandler) Update(c *gin.Context) {
 		return
 	}
 
-	if chipper.Role != entity.ChipperRole && chipper.Role != entity.AdminRole {
-		c.AbortWithStatusJSON(http.StatusForbidden, "Cant set chipper without role Chipper or Admin")
+	if chipper.Status == entity.DisabledStatus && chipper.Id != oldAnimal.ChipperId {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Chipper account is disabled")
 		return
 	}
 
-	_, httpErr = a.locationService.Get(newAnimal.ChippingLocationId)
+	if !a.permissionService.HasPermission(chipper.Role, permission.AnimalsChip) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant set chipper without permission "+permission.AnimalsChip)
+		return
+	}
+
+	chippingLocation, httpErr := a.locationService.Get(newAnimal.ChippingLocationId)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkLocationAccess(c, chippingLocation, false) {
+		return
+	}
 
 	newAnimal.Id = oldAnimal.Id
 
@@ -173,6 +239,18 @@ func (a *AnimalHandler) Update(c *gin.Context) {
 		return
 	}
 
+	// изменённые вес, рост и длина сохраняются в историю замеров в текущей точке животного
+	locationPointId, httpErr := a.animalLocationService.GetCurrentLocationPointId(animalResponse)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	httpErr = a.measurementService.RecordChanges(animalResponse, oldAnimal, authorizedAccountId(c), &locationPointId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
 	c.JSON(http.StatusOK, animalResponse)
 }
 
@@ -188,17 +266,26 @@ func (a *AnimalHandler) Delete(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
 
 	if len(animalResponse.VisitedLocationsId) > 0 {
 		c.AbortWithStatusJSON(http.StatusBadRequest, "animal has visited location points")
 		return
 	}
 
-	err := a.animalService.Delete(id)
+	if a.animalService.HasOffspring(id) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "animal has offspring")
+		return
+	}
+
+	attachments, err := a.animalService.Delete(id)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
 		return
 	}
+	a.attachmentService.DeleteContent(attachments)
 
 	c.Status(http.StatusOK)
 }
@@ -220,6 +307,9 @@ func (a *AnimalHandler) AddAnimalType(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
 
 	_, httpErr = a.animalTypeService.Get(typeId)
 	if httpErr != nil {
@@ -268,6 +358,9 @@ func (a *AnimalHandler) EditAnimalType(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
 
 	_, httpErr = a.animalTypeService.Get(*animalTypeUpdateInput.NewTypeId)
 	if httpErr != nil {
@@ -319,6 +412,9 @@ func (a *AnimalHandler) DeleteAnimalType(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
 
 	_, httpErr = a.animalTypeService.Get(typeId)
 	if httpErr != nil {
@@ -348,3 +444,358 @@ func (a *AnimalHandler) DeleteAnimalType(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, animalResponse)
 }
+
+func (a *AnimalHandler) SetParents(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	parentsInput := &input.AnimalParents{}
+	err := c.BindJSON(&parentsInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	animalResponse, httpErr := a.animalService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
+
+	if parentsInput.BirthDateTime != nil && parentsInput.BirthDateTime.After(animalResponse.ChippingDateTime) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "birthDateTime cant be after chipping")
+		return
+	}
+	if !a.checkParents(c, id, parentsInput, animalResponse.ChippingDateTime) {
+		return
+	}
+
+	animalResponse, httpErr = a.animalService.SetParents(id, parentsInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, animalResponse)
+}
+
+func (a *AnimalHandler) GetAncestors(c *gin.Context) {
+	a.getLineage(c, a.animalService.GetAncestors)
+}
+
+func (a *AnimalHandler) GetDescendants(c *gin.Context) {
+	a.getLineage(c, a.animalService.GetDescendants)
+}
+
+// getLineage Получение родословной животного. Животные недоступных организаций в дерево не попадают
+func (a *AnimalHandler) getLineage(c *gin.Context, getTree func(animalId int, params *filter.LineageParams) (*response.AnimalLineage, *errorHandler.HttpErr)) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	params, httpErr := filter.NewLineageParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animalResponse, httpErr := a.animalService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, false) {
+		return
+	}
+
+	params.Tenant = tenantScope(c)
+	lineage, httpErr := getTree(id, params)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, lineage)
+}
+
+// GetByChipCode Поиск животного по номеру чипа, считанному сканером
+func (a *AnimalHandler) GetByChipCode(c *gin.Context) {
+	code := c.Param("code")
+	httpErr := AnimalValidator.ValidateChipCode(code)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animal, httpErr := a.animalService.GetByChipCode(code)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	// животное недоступной организации не раскрывается даже по идентификатору
+	if !a.animalService.IsAccessible(animal, tenantScope(c)) {
+		c.AbortWithStatusJSON(http.StatusNotFound, fmt.Sprintf("Animal with chip %s does not exists", chip.Normalize(code)))
+		return
+	}
+
+	c.JSON(http.StatusOK, animal)
+}
+
+func (a *AnimalHandler) GetChips(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animal, httpErr := a.animalService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animal, false) {
+		return
+	}
+
+	animalChips, err := a.animalService.GetChips(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, animalChips)
+}
+
+func (a *AnimalHandler) Rechip(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	chipInput := &input.AnimalChip{}
+	err := c.BindJSON(&chipInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	if chipInput.ChipCode == nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "chipCode is missing")
+		return
+	}
+	httpErr = AnimalValidator.ValidateChipCode(*chipInput.ChipCode)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animal, httpErr := a.animalService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animal, true) {
+		return
+	}
+	if animal.LifeStatus == entity.Dead {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Dead animal cant be rechipped")
+		return
+	}
+
+	animal, httpErr = a.animalService.Rechip(animal, *chipInput.ChipCode, authorizedAccountId(c))
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, animal)
+}
+
+func (a *AnimalHandler) GetShares(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animalResponse, httpErr := a.animalService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
+
+	animalShares, err := a.animalService.GetShares(id)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, animalShares)
+}
+
+func (a *AnimalHandler) Share(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	organizationId, httpErr := validator.ValidateAndReturnId(c.Param("organizationId"), "organizationId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animalResponse, httpErr := a.animalService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
+
+	if animalResponse.OrganizationId == nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Animal without organization is visible to everyone")
+		return
+	}
+	if *animalResponse.OrganizationId == organizationId {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "Animal already belongs to organization")
+		return
+	}
+
+	_, httpErr = a.organizationService.Get(organizationId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animalShare, httpErr := a.animalService.Share(id, organizationId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, animalShare)
+}
+
+func (a *AnimalHandler) Unshare(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	organizationId, httpErr := validator.ValidateAndReturnId(c.Param("organizationId"), "organizationId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animalResponse, httpErr := a.animalService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
+
+	httpErr = a.animalService.Unshare(id, organizationId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// checkAnimalAccess Проверка доступа к животному в рамках организации запроса.
+// Животные недоступных организаций не раскрываются, животные, которыми поделились с организацией, и общие животные
+// в активной организации доступны только на чтение
+func checkAnimalAccess(c *gin.Context, animalService service.Animal, animal *response.Animal, write bool) bool {
+	scope := tenantScope(c)
+	if scope.CanAccess(animal.OrganizationId) {
+		if !write || scope.CanWrite(animal.OrganizationId) {
+			return true
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, "Animal without organization is read-only in organization")
+		return false
+	}
+
+	if animalService.IsAccessible(animal, scope) {
+		if !write {
+			return true
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, "Animal shared with organization is read-only")
+		return false
+	}
+
+	c.AbortWithStatusJSON(http.StatusNotFound, fmt.Sprintf("Animal with id %d does not exists", animal.Id))
+	return false
+}
+
+// setInsideLocationIds Заполнение точек внутри зоны фильтра areaId. Недоступная зона не раскрывается
+func setInsideLocationIds(c *gin.Context, areaService service.Area, params *filter.AnimalFilterParams) bool {
+	if params.AreaId == 0 {
+		return true
+	}
+
+	area, httpErr := areaService.Get(params.AreaId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return false
+	}
+	if !checkAreaAccess(c, area, false) {
+		return false
+	}
+
+	params.InsideLocationIds, httpErr = areaService.GetInsideLocationIds(area)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return false
+	}
+	return true
+}
+
+// checkParents Проверка родителей животного animalId, чипированного в chippingDateTime.
+// Для нового животного animalId равен 0
+func (a *AnimalHandler) checkParents(c *gin.Context, animalId int, parents *input.AnimalParents, chippingDateTime time.Time) bool {
+	httpErr := AnimalValidator.ValidateAnimalParentsInput(parents)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return false
+	}
+
+	for gender, parentId := range map[string]*int{entity.Female: parents.MotherId, entity.Male: parents.FatherId} {
+		if parentId == nil {
+			continue
+		}
+
+		parent, httpErr := a.animalService.Get(*parentId)
+		if httpErr != nil {
+			c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+			return false
+		}
+		if !checkAnimalAccess(c, a.animalService, parent, false) {
+			return false
+		}
+
+		httpErr = AnimalValidator.ValidateParent(parent, gender, animalId, parents.BirthDateTime, chippingDateTime)
+		if httpErr != nil {
+			c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+			return false
+		}
+	}
+
+	return true
+}
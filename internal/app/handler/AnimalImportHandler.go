@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/service"
+	"net/http"
+)
+
+// AnimalImportHandler Обработчик запросов массового импорта животных
+type AnimalImportHandler struct {
+	animalImportService service.AnimalImport
+}
+
+func NewAnimalImportHandler(animalImportService service.AnimalImport) *AnimalImportHandler {
+	return &AnimalImportHandler{animalImportService: animalImportService}
+}
+
+// Import Импорт животных из CSV или NDJSON файла в теле запроса. Отчёт по строкам возвращается и при ошибках строк,
+// если в режиме atomic животные не созданы, ответ 400
+func (a *AnimalImportHandler) Import(c *gin.Context) {
+	params, httpErr := filter.NewAnimalImportParams(c.Request.URL.Query(), c.ContentType())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	params.Tenant = tenantScope(c)
+	params.ImportedById = authorizedAccountId(c)
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, a.animalImportService.MaxSize())
+	report, httpErr := a.animalImportService.Import(c.Request.Body, params)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	if params.Mode == filter.AtomicImport && !params.DryRun && report.Failed > 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, report)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
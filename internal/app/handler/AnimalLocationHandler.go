@@ -36,11 +36,21 @@ func (a *AnimalLocationHandler) GetAnimalLocations(c *gin.Context) {
 		return
 	}
 
-	animal, httpErr := a.animalLocationService.GetAnimalLocations(animalId, params)
+	animalResponse, httpErr := a.animalService.Get(animalId)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, false) {
+		return
+	}
+
+	animal, page, httpErr := a.animalLocationService.GetAnimalLocations(animalId, params)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	setPageHeaders(c, page)
 	c.JSON(http.StatusOK, animal)
 }
 
@@ -62,6 +72,9 @@ func (a *AnimalLocationHandler) AddAnimalLocationPoint(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
 
 	if animalResponse.LifeStatus == entity.Dead {
 		c.AbortWithStatusJSON(http.StatusBadRequest, "Animal is dead")
@@ -73,6 +86,9 @@ func (a *AnimalLocationHandler) AddAnimalLocationPoint(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkLocationAccess(c, pointResponse, false) {
+		return
+	}
 
 	if len(animalResponse.VisitedLocationsId) == 0 {
 		if pointResponse.Id == animalResponse.ChippingLocationId {
@@ -91,7 +107,7 @@ func (a *AnimalLocationHandler) AddAnimalLocationPoint(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
-	visitedLocations, httpErr := a.animalLocationService.GetAnimalLocations(animalId, params)
+	visitedLocations, _, httpErr := a.animalLocationService.GetAnimalLocations(animalId, params)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
@@ -136,6 +152,9 @@ func (a *AnimalLocationHandler) EditAnimalLocationPoint(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
 
 	animalLocationsMap := make(map[int]bool)
 	for _, animalLocationId := range animalResponse.VisitedLocationsId {
@@ -146,18 +165,21 @@ func (a *AnimalLocationHandler) EditAnimalLocationPoint(c *gin.Context) {
 		return
 	}
 
-	_, httpErr = a.locationService.Get(*animalLocationPointUpdateInput.LocationPointId)
+	pointResponse, httpErr := a.locationService.Get(*animalLocationPointUpdateInput.LocationPointId)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkLocationAccess(c, pointResponse, false) {
+		return
+	}
 
 	params, httpErr := filter.NewAnimalLocationFilterParams(c.Request.URL.Query())
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
-	visitedLocations, httpErr := a.animalLocationService.GetAnimalLocations(animalId, params)
+	visitedLocations, _, httpErr := a.animalLocationService.GetAnimalLocations(animalId, params)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
@@ -220,6 +242,9 @@ func (a *AnimalLocationHandler) DeleteAnimalLocationPoint(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAnimalAccess(c, a.animalService, animalResponse, true) {
+		return
+	}
 
 	animalLocationsMap := make(map[int]bool)
 	for _, animalLocationId := range animalResponse.VisitedLocationsId {
@@ -235,7 +260,7 @@ func (a *AnimalLocationHandler) DeleteAnimalLocationPoint(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
-	visitedLocations, httpErr := a.animalLocationService.GetAnimalLocations(animalId, params)
+	visitedLocations, _, httpErr := a.animalLocationService.GetAnimalLocations(animalId, params)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
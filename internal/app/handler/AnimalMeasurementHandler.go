@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/internal/app/validator/AnimalMeasurementValidator"
+	"net/http"
+)
+
+// AnimalMeasurementHandler Обработчик запросов для сущности "Замер животного"
+type AnimalMeasurementHandler struct {
+	animalMeasurementService service.AnimalMeasurement
+	animalService            service.Animal
+	animalLocationService    service.AnimalLocation
+	locationService          service.Location
+}
+
+func NewAnimalMeasurementHandler(animalMeasurementService service.AnimalMeasurement, animalService service.Animal, animalLocationService service.AnimalLocation, locationService service.Location) *AnimalMeasurementHandler {
+	return &AnimalMeasurementHandler{animalMeasurementService: animalMeasurementService, animalService: animalService, animalLocationService: animalLocationService, locationService: locationService}
+}
+
+func (a *AnimalMeasurementHandler) GetMeasurements(c *gin.Context) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	params, httpErr := filter.NewAnimalMeasurementFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animal, httpErr := a.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animal, false) {
+		return
+	}
+
+	animalMeasurements, page, httpErr := a.animalMeasurementService.Search(animalId, params)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	setPageHeaders(c, page)
+
+	c.JSON(http.StatusOK, animalMeasurements)
+}
+
+// Create Запись замера при повторной поимке. Без указанной точки замер привязывается к текущей точке животного
+func (a *AnimalMeasurementHandler) Create(c *gin.Context) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animalMeasurementInput := &input.AnimalMeasurement{}
+	err := c.BindJSON(&animalMeasurementInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	animal, httpErr := a.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animal, true) {
+		return
+	}
+
+	httpErr = AnimalMeasurementValidator.ValidateAnimalMeasurementInput(animalMeasurementInput, animal)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	newAnimalMeasurement := mapper.AnimalMeasurementInputToAnimalMeasurement(animalMeasurementInput)
+	newAnimalMeasurement.AnimalId = animalId
+	newAnimalMeasurement.MeasuredById = authorizedAccountId(c)
+
+	if newAnimalMeasurement.LocationPointId != nil {
+		location, httpErr := a.locationService.Get(*newAnimalMeasurement.LocationPointId)
+		if httpErr != nil {
+			c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+			return
+		}
+		if !checkLocationAccess(c, location, false) {
+			return
+		}
+	} else {
+		locationPointId, httpErr := a.animalLocationService.GetCurrentLocationPointId(animal)
+		if httpErr != nil {
+			c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+			return
+		}
+		newAnimalMeasurement.LocationPointId = &locationPointId
+	}
+
+	animalMeasurement, httpErr := a.animalMeasurementService.Create(newAnimalMeasurement)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, animalMeasurement)
+}
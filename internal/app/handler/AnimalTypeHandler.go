@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
 	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/permission"
 	"it-planet-task/internal/app/validator"
 	"it-planet-task/internal/app/validator/AnimalTypeValidator"
 	"net/http"
@@ -14,10 +16,11 @@ import (
 type AnimalTypeHandler struct {
 	animalTypeService service.AnimalType
 	animalService     service.Animal
+	permissionService permission.Permission
 }
 
-func NewAnimalTypeHandler(animalTypeService service.AnimalType, animalService service.Animal) *AnimalTypeHandler {
-	return &AnimalTypeHandler{animalTypeService: animalTypeService, animalService: animalService}
+func NewAnimalTypeHandler(animalTypeService service.AnimalType, animalService service.Animal, permissionService permission.Permission) *AnimalTypeHandler {
+	return &AnimalTypeHandler{animalTypeService: animalTypeService, animalService: animalService, permissionService: permissionService}
 }
 
 func (a *AnimalTypeHandler) Get(c *gin.Context) {
@@ -64,6 +67,8 @@ func (a *AnimalTypeHandler) Create(c *gin.Context) {
 	c.JSON(http.StatusCreated, animalType)
 }
 
+// Update Изменение типа животного. Признак охраняемого типа сохраняется, если не передан,
+// и меняется только ролью с правом animal-types:protect
 func (a *AnimalTypeHandler) Update(c *gin.Context) {
 	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
 	if httpErr != nil {
@@ -71,12 +76,13 @@ func (a *AnimalTypeHandler) Update(c *gin.Context) {
 		return
 	}
 
-	newAnimalType := &entity.AnimalType{}
-	err := c.BindJSON(&newAnimalType)
+	animalTypeInput := &input.AnimalType{}
+	err := c.BindJSON(&animalTypeInput)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
 		return
 	}
+	newAnimalType := &entity.AnimalType{Type: animalTypeInput.Type}
 
 	duplicateAnimalType := a.animalTypeService.GetByType(newAnimalType)
 	if duplicateAnimalType.Id != 0 && id != duplicateAnimalType.Id {
@@ -84,7 +90,7 @@ func (a *AnimalTypeHandler) Update(c *gin.Context) {
 		return
 	}
 
-	_, httpErr = a.animalTypeService.Get(id)
+	oldAnimalType, httpErr := a.animalTypeService.Get(id)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
@@ -96,6 +102,15 @@ func (a *AnimalTypeHandler) Update(c *gin.Context) {
 		return
 	}
 
+	newAnimalType.Sensitive = oldAnimalType.Sensitive
+	if animalTypeInput.Sensitive != nil && *animalTypeInput.Sensitive != oldAnimalType.Sensitive {
+		if !hasPermission(c, a.permissionService, permission.AnimalTypesProtect) {
+			c.AbortWithStatusJSON(http.StatusForbidden, "Changing sensitive flag of animal type is not allowed")
+			return
+		}
+		newAnimalType.Sensitive = *animalTypeInput.Sensitive
+	}
+
 	newAnimalType.Id = id
 	animalType, _ := a.animalTypeService.Update(newAnimalType)
 
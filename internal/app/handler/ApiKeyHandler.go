@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/internal/app/validator/ApiKeyValidator"
+	"net/http"
+)
+
+// ApiKeyHandler Обработчик запросов для сущности "API ключ"
+type ApiKeyHandler struct {
+	apiKeyService  service.ApiKey
+	accountService service.Account
+}
+
+func NewApiKeyHandler(apiKeyService service.ApiKey, accountService service.Account) *ApiKeyHandler {
+	return &ApiKeyHandler{apiKeyService: apiKeyService, accountService: accountService}
+}
+
+func (a *ApiKeyHandler) Get(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	apiKey, httpErr := a.apiKeyService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, apiKey)
+}
+
+func (a *ApiKeyHandler) Search(c *gin.Context) {
+	params, httpErr := filter.NewApiKeyFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	apiKeys, page, err := a.apiKeyService.Search(params)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	setPageHeaders(c, page)
+
+	c.JSON(http.StatusOK, apiKeys)
+}
+
+func (a *ApiKeyHandler) Create(c *gin.Context) {
+	apiKeyInput := &input.ApiKey{}
+	err := c.BindJSON(&apiKeyInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr := ApiKeyValidator.ValidateApiKeyInput(apiKeyInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	_, httpErr = a.accountService.Get(*apiKeyInput.AccountId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	apiKey, err := a.apiKeyService.Create(mapper.ApiKeyInputToApiKey(apiKeyInput))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, apiKey)
+}
+
+func (a *ApiKeyHandler) Rotate(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	apiKey, httpErr := a.apiKeyService.Rotate(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, apiKey)
+}
+
+func (a *ApiKeyHandler) Revoke(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	httpErr = a.apiKeyService.Revoke(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"it-planet-task/internal/app/filter"
 	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/repository"
 	"it-planet-task/internal/app/service"
 	"it-planet-task/internal/app/validator"
@@ -32,6 +34,9 @@ func (a *AreaHandler) Get(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAreaAccess(c, area, false) {
+		return
+	}
 
 	c.JSON(http.StatusOK, area)
 }
@@ -50,6 +55,8 @@ func (a *AreaHandler) Create(c *gin.Context) {
 		return
 	}
 
+	newArea.OrganizationId = tenantScope(c).OrganizationId
+
 	area, httpErr := a.areaService.Create(newArea)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
@@ -65,11 +72,14 @@ func (a *AreaHandler) Update(c *gin.Context) {
 		return
 	}
 
-	_, httpErr = a.areaService.Get(id)
+	oldArea, httpErr := a.areaService.Get(id)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAreaAccess(c, oldArea, true) {
+		return
+	}
 
 	newArea := &entity.Area{}
 	err := c.BindJSON(&newArea)
@@ -85,6 +95,7 @@ func (a *AreaHandler) Update(c *gin.Context) {
 	}
 
 	newArea.Id = id
+	newArea.OrganizationId = oldArea.OrganizationId
 
 	area, httpErr := a.areaService.Update(newArea)
 	if httpErr != nil {
@@ -102,11 +113,14 @@ func (a *AreaHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	_, httpErr = a.areaService.Get(id)
+	area, httpErr := a.areaService.Get(id)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkAreaAccess(c, area, true) {
+		return
+	}
 
 	err := a.areaService.Delete(id)
 	if err != nil {
@@ -124,11 +138,21 @@ func (a *AreaHandler) Analytics(c *gin.Context) {
 		return
 	}
 
+	area, httpErr := a.areaService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAreaAccess(c, area, false) {
+		return
+	}
+
 	params, httpErr := filter.NewAreaAnalyticsFilterParams(c.Request.URL.Query())
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	params.Tenant = tenantScope(c)
 
 	areaAnalyticsResponse, httpErr := a.areaService.Analytics(id, params)
 	if httpErr != nil {
@@ -138,3 +162,19 @@ func (a *AreaHandler) Analytics(c *gin.Context) {
 
 	c.JSON(http.StatusOK, areaAnalyticsResponse)
 }
+
+// checkAreaAccess Проверка, что зона принадлежит доступной организации. Зоны недоступных организаций не раскрываются,
+// общие зоны в активной организации доступны только на чтение
+func checkAreaAccess(c *gin.Context, area *response.Area, write bool) bool {
+	scope := tenantScope(c)
+	if scope.CanAccess(area.OrganizationId) {
+		if !write || scope.CanWrite(area.OrganizationId) {
+			return true
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, "Area without organization is read-only in organization")
+		return false
+	}
+
+	c.AbortWithStatusJSON(http.StatusNotFound, fmt.Sprintf("Area with id %d does not exists", area.Id))
+	return false
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/thumbnail"
+	"it-planet-task/internal/app/validator"
+	"mime"
+	"net/http"
+)
+
+// multipartOverhead запас на заголовки multipart сверх максимального размера файла
+const multipartOverhead = 1 << 20
+
+// AttachmentHandler Обработчик запросов для сущности "Вложение животного"
+type AttachmentHandler struct {
+	attachmentService service.Attachment
+	animalService     service.Animal
+}
+
+func NewAttachmentHandler(attachmentService service.Attachment, animalService service.Animal) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService, animalService: animalService}
+}
+
+func (a *AttachmentHandler) GetAttachments(c *gin.Context) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	params, httpErr := filter.NewAttachmentFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animal, httpErr := a.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animal, false) {
+		return
+	}
+
+	attachments, page, httpErr := a.attachmentService.Search(animalId, params)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	setPageHeaders(c, page)
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// Create Загрузка файла в поле file формы multipart/form-data
+func (a *AttachmentHandler) Create(c *gin.Context) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animal, httpErr := a.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, a.animalService, animal, true) {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, a.attachmentService.MaxSize()+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, fmt.Sprintf("file must be at most %d bytes", a.attachmentService.MaxSize()))
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	attachment, httpErr := a.attachmentService.Create(&entity.Attachment{
+		AnimalId:     animalId,
+		FileName:     fileHeader.Filename,
+		Size:         fileHeader.Size,
+		UploadedById: authorizedAccountId(c),
+	}, file)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+func (a *AttachmentHandler) Download(c *gin.Context) {
+	a.download(c, false)
+}
+
+func (a *AttachmentHandler) DownloadThumbnail(c *gin.Context) {
+	a.download(c, true)
+}
+
+func (a *AttachmentHandler) Delete(c *gin.Context) {
+	attachment, ok := a.getAttachment(c, true)
+	if !ok {
+		return
+	}
+
+	httpErr := a.attachmentService.Delete(attachment.Id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// download Отдача содержимого вложения. Оригинал отдаётся как файл для скачивания, миниатюра - для показа
+func (a *AttachmentHandler) download(c *gin.Context, isThumbnail bool) {
+	attachment, ok := a.getAttachment(c, false)
+	if !ok {
+		return
+	}
+
+	content, httpErr := a.attachmentService.Open(attachment.Id, isThumbnail)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	defer content.Close()
+
+	disposition, contentType, size := "attachment", attachment.ContentType, attachment.Size
+	if isThumbnail {
+		disposition, contentType, size = "inline", thumbnail.ContentType, -1
+	}
+
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, size, contentType, content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName}),
+	})
+}
+
+// getAttachment Получение вложения из пути запроса с проверкой доступа к животному.
+// Вложение другого животного считается несуществующим
+func (a *AttachmentHandler) getAttachment(c *gin.Context, write bool) (*response.Attachment, bool) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return nil, false
+	}
+	attachmentId, httpErr := validator.ValidateAndReturnId(c.Param("attachmentId"), "attachmentId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return nil, false
+	}
+
+	animal, httpErr := a.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return nil, false
+	}
+	if !checkAnimalAccess(c, a.animalService, animal, write) {
+		return nil, false
+	}
+
+	attachment, httpErr := a.attachmentService.Get(attachmentId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return nil, false
+	}
+	if attachment.AnimalId != animalId {
+		c.AbortWithStatusJSON(http.StatusNotFound, fmt.Sprintf("Attachment with id %d does not exists", attachmentId))
+		return nil, false
+	}
+
+	return attachment, true
+}
@@ -2,20 +2,32 @@ package handler
 
 import (
 	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/mapper"
 	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/accountcache"
+	"it-planet-task/internal/app/service/audit"
+	"it-planet-task/internal/app/service/throttle"
+	"it-planet-task/internal/app/validator"
 	"it-planet-task/internal/app/validator/AccountValidator"
+	"it-planet-task/internal/app/validator/AuthValidator"
 	"net/http"
 )
 
 // AuthHandler Обработчик запросов, связанных с аутентификацией
 type AuthHandler struct {
-	authService    service.Auth
-	accountService service.Account
+	authService     service.Auth
+	accountService  service.Account
+	throttleService throttle.Throttle
+	resetThrottle   throttle.Throttle
+	accountCache    accountcache.AccountCache
+	auditService    audit.Audit
 }
 
-func NewAuthHandler(authService service.Auth, accountService service.Account) *AuthHandler {
-	return &AuthHandler{authService: authService, accountService: accountService}
+func NewAuthHandler(authService service.Auth, accountService service.Account, throttleService throttle.Throttle, resetThrottle throttle.Throttle, accountCache accountcache.AccountCache, auditService audit.Audit) *AuthHandler {
+	return &AuthHandler{authService: authService, accountService: accountService, throttleService: throttleService, resetThrottle: resetThrottle, accountCache: accountCache, auditService: auditService}
 }
 
 func (a *AuthHandler) Register(c *gin.Context) {
@@ -51,3 +63,269 @@ func (a *AuthHandler) Register(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, account)
 }
+
+func (a *AuthHandler) Login(c *gin.Context) {
+	loginInput := &input.Login{}
+	err := c.BindJSON(&loginInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr := AuthValidator.ValidateLoginInput(loginInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	emailKey := throttle.NewEmailKey(*loginInput.Email)
+	ipKey := throttle.NewIpKey(c.ClientIP())
+	retryAfter := a.throttleService.RetryAfter(emailKey, ipKey)
+	if retryAfter > 0 {
+		a.recordLogin(c, *loginInput.Email, "too many failed attempts", false)
+		c.Header("Retry-After", throttle.RetryAfterSeconds(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, "Too many failed login attempts")
+		return
+	}
+
+	tokens, httpErr := a.authService.Login(&entity.Account{Email: *loginInput.Email, Password: *loginInput.Password})
+	if httpErr != nil {
+		if httpErr.StatusCode == http.StatusUnauthorized {
+			a.throttleService.Failure(emailKey, ipKey)
+		}
+		a.recordLogin(c, *loginInput.Email, httpErr.Err.Error(), true)
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	a.throttleService.Success(emailKey)
+	a.recordLogin(c, *loginInput.Email, "", true)
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (a *AuthHandler) Refresh(c *gin.Context) {
+	refreshTokenInput := &input.RefreshToken{}
+	err := c.BindJSON(&refreshTokenInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr := AuthValidator.ValidateRefreshTokenInput(refreshTokenInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	tokens, httpErr := a.authService.Refresh(*refreshTokenInput.RefreshToken)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (a *AuthHandler) Logout(c *gin.Context) {
+	refreshTokenInput := &input.RefreshToken{}
+	err := c.BindJSON(&refreshTokenInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr := AuthValidator.ValidateRefreshTokenInput(refreshTokenInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	httpErr = a.authService.Logout(*refreshTokenInput.RefreshToken)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) ChangePassword(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	authorizedAccountAny, _ := c.Get("account")
+	authorizedAccount := authorizedAccountAny.(*entity.Account)
+	if id != authorizedAccount.Id {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant change another's password")
+		return
+	}
+
+	passwordChangeInput := &input.PasswordChange{}
+	err := c.BindJSON(&passwordChangeInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr = AuthValidator.ValidatePasswordChangeInput(passwordChangeInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	// проверка старого пароля учитывается наравне со входом, чтобы украденной сессией нельзя было подбирать пароль
+	emailKey := throttle.NewEmailKey(authorizedAccount.Email)
+	ipKey := throttle.NewIpKey(c.ClientIP())
+	retryAfter := a.throttleService.RetryAfter(emailKey, ipKey)
+	if retryAfter > 0 {
+		c.Header("Retry-After", throttle.RetryAfterSeconds(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, "Too many failed password attempts")
+		return
+	}
+
+	httpErr = a.authService.ChangePassword(id, *passwordChangeInput.OldPassword, *passwordChangeInput.NewPassword)
+	if httpErr != nil {
+		if httpErr.StatusCode == http.StatusForbidden {
+			a.throttleService.Failure(emailKey, ipKey)
+		}
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	a.throttleService.Success(emailKey)
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	passwordResetRequestInput := &input.PasswordResetRequest{}
+	err := c.BindJSON(&passwordResetRequestInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr := AuthValidator.ValidatePasswordResetRequestInput(passwordResetRequestInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	// каждый запрос учитывается как попытка, чтобы через сброс пароля нельзя было рассылать письма без ограничений
+	emailKey := throttle.NewEmailKey(*passwordResetRequestInput.Email)
+	ipKey := throttle.NewIpKey(c.ClientIP())
+	retryAfter := a.resetThrottle.RetryAfter(emailKey, ipKey)
+	if retryAfter > 0 {
+		c.Header("Retry-After", throttle.RetryAfterSeconds(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, "Too many password reset requests")
+		return
+	}
+	a.resetThrottle.Failure(emailKey, ipKey)
+
+	httpErr = a.authService.RequestPasswordReset(*passwordResetRequestInput.Email)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (a *AuthHandler) RequestAccountPasswordReset(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	httpErr = a.authService.RequestAccountPasswordReset(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (a *AuthHandler) ResetPassword(c *gin.Context) {
+	passwordResetInput := &input.PasswordReset{}
+	err := c.BindJSON(&passwordResetInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr := AuthValidator.ValidatePasswordResetInput(passwordResetInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	httpErr = a.authService.ResetPassword(*passwordResetInput.Token, *passwordResetInput.NewPassword)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) GetLockouts(c *gin.Context) {
+	c.JSON(http.StatusOK, mapper.LockoutsToLockoutResponses(a.throttleService.Lockouts()))
+}
+
+func (a *AuthHandler) ClearLockout(c *gin.Context) {
+	var key throttle.Key
+	if c.Query("email") != "" {
+		key = throttle.NewEmailKey(c.Query("email"))
+	} else if c.Query("ip") != "" {
+		key = throttle.NewIpKey(c.Query("ip"))
+	} else {
+		c.AbortWithStatusJSON(http.StatusBadRequest, "email or ip is missing")
+		return
+	}
+
+	if !a.throttleService.Clear(key) {
+		c.AbortWithStatusJSON(http.StatusNotFound, "Lockout does not exists")
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (a *AuthHandler) GetCacheStats(c *gin.Context) {
+	stats := a.accountCache.Stats()
+	c.JSON(http.StatusOK, &response.CacheStats{
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		Evictions: stats.Evictions,
+		Size:      stats.Size,
+		HitRatio:  stats.HitRatio(),
+	})
+}
+
+// recordLogin Запись попытки входа по паролю в журнал аутентификаций. Пустая причина означает успешный вход
+func (a *AuthHandler) recordLogin(c *gin.Context, email, reason string, lookupAccount bool) {
+	securityEvent := &entity.SecurityEvent{
+		Email:     email,
+		Type:      entity.AuthSuccessEvent,
+		Method:    entity.LoginAuthMethod,
+		Reason:    reason,
+		Ip:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	if reason != "" {
+		securityEvent.Type = entity.AuthFailureEvent
+	}
+
+	if lookupAccount {
+		account, err := a.accountService.GetByEmail(&entity.Account{Email: email})
+		if err == nil && account.Id != 0 {
+			securityEvent.AccountId = &account.Id
+		}
+	}
+
+	a.auditService.Record(securityEvent)
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/exporter"
+	"it-planet-task/internal/app/service/permission"
+	"it-planet-task/internal/app/validator"
+	"log"
+	"net/http"
+)
+
+// ExportHandler Обработчик запросов выгрузки животных, посещённых точек и зон в файл
+type ExportHandler struct {
+	exportService     service.Export
+	animalService     service.Animal
+	areaService       service.Area
+	permissionService permission.Permission
+}
+
+func NewExportHandler(exportService service.Export, animalService service.Animal, areaService service.Area, permissionService permission.Permission) *ExportHandler {
+	return &ExportHandler{exportService: exportService, animalService: animalService, areaService: areaService, permissionService: permissionService}
+}
+
+// ExportAnimals Выгрузка животных с параметрами фильтра GET /animals/search
+func (e *ExportHandler) ExportAnimals(c *gin.Context) {
+	format, httpErr := filter.ValidateAndReturnExportFormat(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	params, httpErr := filter.NewAnimalFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	params.Tenant = tenantScope(c)
+	if !setInsideLocationIds(c, e.areaService, params) {
+		return
+	}
+
+	locationViewer := viewer(c, e.permissionService)
+	streamExport(c, "animals", format, func(w io.Writer) error {
+		return e.exportService.ExportAnimals(w, format, params, locationViewer)
+	})
+}
+
+// ExportAnimalLocations Выгрузка посещённых животным точек с параметрами фильтра GET /animals/:id/locations
+func (e *ExportHandler) ExportAnimalLocations(c *gin.Context) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	format, httpErr := filter.ValidateAndReturnExportFormat(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	params, httpErr := filter.NewAnimalLocationFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animal, httpErr := e.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, e.animalService, animal, false) {
+		return
+	}
+
+	locationViewer := viewer(c, e.permissionService)
+	streamExport(c, fmt.Sprintf("animal-%d-locations", animalId), format, func(w io.Writer) error {
+		return e.exportService.ExportAnimalLocations(w, format, animalId, params, locationViewer)
+	})
+}
+
+// ExportAreas Выгрузка доступных зон
+func (e *ExportHandler) ExportAreas(c *gin.Context) {
+	format, httpErr := filter.ValidateAndReturnExportFormat(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	params, httpErr := filter.NewAreaFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	params.Tenant = tenantScope(c)
+
+	streamExport(c, "areas", format, func(w io.Writer) error {
+		return e.exportService.ExportAreas(w, format, params)
+	})
+}
+
+// streamExport Запись выгрузки в ответ. Если ошибка произошла до отправки первых данных, возвращается 400,
+// иначе соединение обрывается, чтобы клиент не принял неполный файл за целый
+func streamExport(c *gin.Context, name, format string, export func(w io.Writer) error) {
+	c.Header("Content-Type", exporter.ContentType(format))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+
+	err := export(c.Writer)
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Println("export:", err)
+	panic(http.ErrAbortHandler)
+}
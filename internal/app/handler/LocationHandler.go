@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
 	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/permission"
 	"it-planet-task/internal/app/validator"
 	"it-planet-task/internal/app/validator/LocationValidator"
 	"net/http"
@@ -13,12 +16,13 @@ import (
 
 // LocationHandler Обработчик запросов для сущности "Локация"
 type LocationHandler struct {
-	locationService service.Location
-	animalService   service.Animal
+	locationService   service.Location
+	animalService     service.Animal
+	permissionService permission.Permission
 }
 
-func NewLocationHandler(locationService service.Location, animalService service.Animal) *LocationHandler {
-	return &LocationHandler{locationService: locationService, animalService: animalService}
+func NewLocationHandler(locationService service.Location, animalService service.Animal, permissionService permission.Permission) *LocationHandler {
+	return &LocationHandler{locationService: locationService, animalService: animalService, permissionService: permissionService}
 }
 
 func (l *LocationHandler) Get(c *gin.Context) {
@@ -33,6 +37,14 @@ func (l *LocationHandler) Get(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkLocationAccess(c, location, false) {
+		return
+	}
+
+	locationViewer := viewer(c, l.permissionService)
+	if !locationViewer.ExactLocations {
+		location = mapper.RedactLocationResponse(location, locationViewer, l.locationService.IsSensitive(id))
+	}
 
 	c.JSON(http.StatusOK, location)
 }
@@ -44,8 +56,9 @@ func (l *LocationHandler) GetByCoordinates(c *gin.Context) {
 		return
 	}
 	location := &entity.Location{
-		Latitude:  params.Latitude,
-		Longitude: params.Longitude,
+		Latitude:       params.Latitude,
+		Longitude:      params.Longitude,
+		OrganizationId: tenantScope(c).OrganizationId,
 	}
 
 	httpErr = LocationValidator.ValidateLocation(location)
@@ -60,6 +73,12 @@ func (l *LocationHandler) GetByCoordinates(c *gin.Context) {
 		return
 	}
 
+	// поиск по точным координатам не должен подтверждать охраняемую точку тому, кто видит её только огрублённой
+	if !viewer(c, l.permissionService).ExactLocations && l.locationService.IsSensitive(locationResponse.Id) {
+		c.AbortWithStatusJSON(http.StatusNotFound, "location with these coordinates does not exists")
+		return
+	}
+
 	c.JSON(http.StatusOK, locationResponse.Id)
 }
 
@@ -154,6 +173,7 @@ func (l *LocationHandler) Create(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, httpErr.Err.Error())
 		return
 	}
+	newLocation.OrganizationId = tenantScope(c).OrganizationId
 
 	_, httpErr = l.locationService.GetByCoordinates(newLocation)
 	if httpErr == nil {
@@ -182,6 +202,9 @@ func (l *LocationHandler) Update(c *gin.Context) {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkLocationAccess(c, oldLocation, true) {
+		return
+	}
 
 	newLocation := &entity.Location{}
 	err := c.BindJSON(&newLocation)
@@ -195,6 +218,7 @@ func (l *LocationHandler) Update(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, httpErr.Err.Error())
 		return
 	}
+	newLocation.OrganizationId = oldLocation.OrganizationId
 
 	duplicateLocation, httpErr := l.locationService.GetByCoordinates(newLocation)
 	if httpErr == nil && oldLocation.Id != duplicateLocation.Id {
@@ -219,11 +243,14 @@ func (l *LocationHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	_, httpErr = l.locationService.Get(id)
+	location, httpErr := l.locationService.Get(id)
 	if httpErr != nil {
 		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
 		return
 	}
+	if !checkLocationAccess(c, location, true) {
+		return
+	}
 
 	animals, err := l.animalService.GetAnimalsByLocationId(id)
 	if err != nil {
@@ -243,3 +270,19 @@ func (l *LocationHandler) Delete(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// checkLocationAccess Проверка, что точка локации принадлежит доступной организации.
+// Точки недоступных организаций не раскрываются, общие точки в активной организации доступны только на чтение
+func checkLocationAccess(c *gin.Context, location *response.Location, write bool) bool {
+	scope := tenantScope(c)
+	if scope.CanAccess(location.OrganizationId) {
+		if !write || scope.CanWrite(location.OrganizationId) {
+			return true
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, "Location without organization is read-only in organization")
+		return false
+	}
+
+	c.AbortWithStatusJSON(http.StatusNotFound, fmt.Sprintf("Location with id %d does not exists", location.Id))
+	return false
+}
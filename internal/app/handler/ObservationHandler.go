@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/permission"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/internal/app/validator/ObservationValidator"
+	"net/http"
+)
+
+// ObservationHandler Обработчик запросов для сущности "Наблюдение"
+type ObservationHandler struct {
+	observationService service.Observation
+	animalService      service.Animal
+	permissionService  permission.Permission
+}
+
+func NewObservationHandler(observationService service.Observation, animalService service.Animal, permissionService permission.Permission) *ObservationHandler {
+	return &ObservationHandler{observationService: observationService, animalService: animalService, permissionService: permissionService}
+}
+
+func (o *ObservationHandler) GetObservations(c *gin.Context) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	params, httpErr := filter.NewObservationFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	params.AnimalId = &animalId
+
+	animal, httpErr := o.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, o.animalService, animal, false) {
+		return
+	}
+
+	o.search(c, params)
+}
+
+// Search Полнотекстовый поиск по наблюдениям всех доступных животных
+func (o *ObservationHandler) Search(c *gin.Context) {
+	params, httpErr := filter.NewObservationFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	params.Tenant = tenantScope(c)
+
+	o.search(c, params)
+}
+
+func (o *ObservationHandler) Get(c *gin.Context) {
+	observation, ok := o.getObservation(c, false)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, observation)
+}
+
+func (o *ObservationHandler) Create(c *gin.Context) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	observationInput := &input.Observation{}
+	err := c.BindJSON(&observationInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	animal, httpErr := o.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	if !checkAnimalAccess(c, o.animalService, animal, true) {
+		return
+	}
+
+	httpErr = ObservationValidator.ValidateObservationInput(observationInput, animal)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	newObservation := mapper.ObservationInputToObservation(observationInput)
+	newObservation.AnimalId = animalId
+	newObservation.AuthorId = authorizedAccountId(c)
+
+	observation, httpErr := o.observationService.Create(newObservation)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, observation)
+}
+
+func (o *ObservationHandler) Update(c *gin.Context) {
+	oldObservation, ok := o.getObservation(c, true)
+	if !ok || !o.checkAuthor(c, oldObservation) {
+		return
+	}
+
+	observationInput := &input.Observation{}
+	err := c.BindJSON(&observationInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	animal, httpErr := o.animalService.Get(oldObservation.AnimalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	httpErr = ObservationValidator.ValidateObservationInput(observationInput, animal)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	newObservation := mapper.ObservationInputToObservation(observationInput)
+	newObservation.Id = oldObservation.Id
+
+	observation, httpErr := o.observationService.Update(newObservation)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, observation)
+}
+
+func (o *ObservationHandler) Delete(c *gin.Context) {
+	observation, ok := o.getObservation(c, true)
+	if !ok || !o.checkAuthor(c, observation) {
+		return
+	}
+
+	httpErr := o.observationService.Delete(observation.Id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (o *ObservationHandler) search(c *gin.Context, params *filter.ObservationFilterParams) {
+	observations, page, httpErr := o.observationService.Search(params)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	setPageHeaders(c, page)
+
+	c.JSON(http.StatusOK, observations)
+}
+
+// getObservation Получение наблюдения из пути запроса с проверкой доступа к животному.
+// Наблюдение другого животного считается несуществующим
+func (o *ObservationHandler) getObservation(c *gin.Context, write bool) (*response.Observation, bool) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return nil, false
+	}
+	observationId, httpErr := validator.ValidateAndReturnId(c.Param("observationId"), "observationId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return nil, false
+	}
+
+	animal, httpErr := o.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return nil, false
+	}
+	if !checkAnimalAccess(c, o.animalService, animal, write) {
+		return nil, false
+	}
+
+	observation, httpErr := o.observationService.Get(observationId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return nil, false
+	}
+	if observation.AnimalId != animalId {
+		c.AbortWithStatusJSON(http.StatusNotFound, fmt.Sprintf("Observation with id %d does not exists", observationId))
+		return nil, false
+	}
+
+	return observation, true
+}
+
+// checkAuthor Изменять и удалять наблюдение может его автор или аккаунт с правом observations:manage
+func (o *ObservationHandler) checkAuthor(c *gin.Context, observation *response.Observation) bool {
+	accountId := authorizedAccountId(c)
+	if accountId != nil && observation.AuthorId != nil && *accountId == *observation.AuthorId {
+		return true
+	}
+	if hasPermission(c, o.permissionService, permission.ObservationsManage) {
+		return true
+	}
+
+	c.AbortWithStatusJSON(http.StatusForbidden, "Only author can change observation")
+	return false
+}
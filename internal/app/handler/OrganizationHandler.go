@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/permission"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/internal/app/validator/OrganizationValidator"
+	"net/http"
+)
+
+// OrganizationHandler Обработчик запросов для сущности "Организация"
+type OrganizationHandler struct {
+	organizationService service.Organization
+	accountService      service.Account
+	permissionService   permission.Permission
+}
+
+func NewOrganizationHandler(organizationService service.Organization, accountService service.Account, permissionService permission.Permission) *OrganizationHandler {
+	return &OrganizationHandler{organizationService: organizationService, accountService: accountService, permissionService: permissionService}
+}
+
+func (o *OrganizationHandler) Get(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	if !tenantScope(c).CanAccess(&id) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant get another organization")
+		return
+	}
+
+	organization, httpErr := o.organizationService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+func (o *OrganizationHandler) Search(c *gin.Context) {
+	params, httpErr := filter.NewOrganizationFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	authorizedAccountAny, _ := c.Get("account")
+	authorizedAccount := authorizedAccountAny.(*entity.Account)
+	if !o.permissionService.HasPermission(authorizedAccount.Role, permission.OrganizationsAny) {
+		params.MemberId = authorizedAccount.Id
+	}
+
+	organizations, page, err := o.organizationService.Search(params)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	setPageHeaders(c, page)
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+func (o *OrganizationHandler) Create(c *gin.Context) {
+	organizationInput := &input.Organization{}
+	err := c.BindJSON(&organizationInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr := OrganizationValidator.ValidateOrganizationInput(organizationInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	organization, httpErr := o.organizationService.Create(&entity.Organization{Name: *organizationInput.Name})
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusCreated, organization)
+}
+
+func (o *OrganizationHandler) Update(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	organizationInput := &input.Organization{}
+	err := c.BindJSON(&organizationInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr = OrganizationValidator.ValidateOrganizationInput(organizationInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	organization, httpErr := o.organizationService.Update(&entity.Organization{Id: id, Name: *organizationInput.Name})
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+func (o *OrganizationHandler) GetMembers(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	if !tenantScope(c).CanAccess(&id) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant get members of another organization")
+		return
+	}
+
+	members, httpErr := o.organizationService.GetMembers(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (o *OrganizationHandler) SetMember(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	accountId, httpErr := validator.ValidateAndReturnId(c.Param("accountId"), "accountId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	if !tenantScope(c).CanAccess(&id) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant edit members of another organization")
+		return
+	}
+
+	membershipInput := &input.Membership{}
+	err := c.BindJSON(&membershipInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr = OrganizationValidator.ValidateMembershipInput(membershipInput, o.permissionService.Roles())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	_, httpErr = o.accountService.Get(accountId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	membership, httpErr := o.organizationService.SetMember(&entity.Membership{
+		OrganizationId: id,
+		AccountId:      accountId,
+		Role:           *membershipInput.Role,
+	})
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, membership)
+}
+
+func (o *OrganizationHandler) RemoveMember(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	accountId, httpErr := validator.ValidateAndReturnId(c.Param("accountId"), "accountId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	if !tenantScope(c).CanAccess(&id) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant edit members of another organization")
+		return
+	}
+
+	httpErr = o.organizationService.RemoveMember(id, accountId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// tenantScope Организация, в рамках которой выполняется запрос. Заполняется middleware аутентификации
+func tenantScope(c *gin.Context) *filter.TenantScope {
+	scope, ok := c.Get("tenant")
+	if !ok {
+		return &filter.TenantScope{}
+	}
+	return scope.(*filter.TenantScope)
+}
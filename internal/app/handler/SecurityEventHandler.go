@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/audit"
+	"it-planet-task/internal/app/service/permission"
+	"it-planet-task/internal/app/validator"
+	"net/http"
+)
+
+// SecurityEventHandler Обработчик запросов к журналу аутентификаций
+type SecurityEventHandler struct {
+	auditService      audit.Audit
+	accountService    service.Account
+	permissionService permission.Permission
+}
+
+func NewSecurityEventHandler(auditService audit.Audit, accountService service.Account, permissionService permission.Permission) *SecurityEventHandler {
+	return &SecurityEventHandler{auditService: auditService, accountService: accountService, permissionService: permissionService}
+}
+
+func (s *SecurityEventHandler) GetAccountEvents(c *gin.Context) {
+	id, httpErr := validator.ValidateAndReturnId(c.Param("id"), "id")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	authorizedAccountAny, _ := c.Get("account")
+	authorizedAccount := authorizedAccountAny.(*entity.Account)
+	if !s.permissionService.HasPermission(authorizedAccount.Role, permission.SecurityEventsReadAny) && (id != authorizedAccount.Id) {
+		c.AbortWithStatusJSON(http.StatusForbidden, "Cant get security events of another's account")
+		return
+	}
+
+	_, httpErr = s.accountService.Get(id)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	params, httpErr := filter.NewSecurityEventFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	params.AccountId = id
+
+	securityEvents, page, err := s.auditService.Search(params)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	setPageHeaders(c, page)
+
+	c.JSON(http.StatusOK, securityEvents)
+}
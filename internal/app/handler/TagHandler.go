@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/internal/app/validator/TagValidator"
+	"net/http"
+)
+
+// TagHandler Обработчик запросов для сущности "Метка"
+type TagHandler struct {
+	tagService    service.Tag
+	animalService service.Animal
+}
+
+func NewTagHandler(tagService service.Tag, animalService service.Animal) *TagHandler {
+	return &TagHandler{tagService: tagService, animalService: animalService}
+}
+
+// Search Подсказки меток по началу имени
+func (t *TagHandler) Search(c *gin.Context) {
+	params, httpErr := filter.NewTagFilterParams(c.Request.URL.Query())
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+	params.Tenant = tenantScope(c)
+
+	tags, httpErr := t.tagService.Search(params)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, tags)
+}
+
+func (t *TagHandler) AddAnimalTag(c *gin.Context) {
+	animalId, tag, hasTag, ok := t.getAnimalTag(c)
+	if !ok {
+		return
+	}
+	if hasTag {
+		c.AbortWithStatusJSON(http.StatusConflict, fmt.Sprintf("Animal already has tag %s", tag))
+		return
+	}
+
+	t.updateAnimalTags(c, &input.AnimalTags{AnimalIds: []int{animalId}, Add: []string{tag}}, http.StatusCreated)
+}
+
+func (t *TagHandler) DeleteAnimalTag(c *gin.Context) {
+	animalId, tag, hasTag, ok := t.getAnimalTag(c)
+	if !ok {
+		return
+	}
+	if !hasTag {
+		c.AbortWithStatusJSON(http.StatusNotFound, fmt.Sprintf("Animal does not have tag %s", tag))
+		return
+	}
+
+	t.updateAnimalTags(c, &input.AnimalTags{AnimalIds: []int{animalId}, Remove: []string{tag}}, http.StatusOK)
+}
+
+// UpdateAnimalsTags Добавление и удаление меток сразу у группы животных. Все животные должны быть доступны на запись
+func (t *TagHandler) UpdateAnimalsTags(c *gin.Context) {
+	animalTagsInput := &input.AnimalTags{}
+	err := c.BindJSON(&animalTagsInput)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	httpErr := TagValidator.ValidateAnimalTagsInput(animalTagsInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	httpErr = t.animalService.CheckWritable(animalTagsInput.AnimalIds, tenantScope(c))
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animalTags, httpErr := t.tagService.UpdateAnimalTags(animalTagsInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, animalTags)
+}
+
+// getAnimalTag Разбор животного и метки из пути запроса, проверка доступа к животному на запись
+// и наличия у него метки
+func (t *TagHandler) getAnimalTag(c *gin.Context) (int, string, bool, bool) {
+	animalId, httpErr := validator.ValidateAndReturnId(c.Param("id"), "animalId")
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return 0, "", false, false
+	}
+	tag, httpErr := TagValidator.NormalizeTag(c.Param("tag"))
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return 0, "", false, false
+	}
+
+	animal, httpErr := t.animalService.Get(animalId)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return 0, "", false, false
+	}
+	if !checkAnimalAccess(c, t.animalService, animal, true) {
+		return 0, "", false, false
+	}
+
+	for _, animalTag := range animal.Tags {
+		if animalTag == tag {
+			return animalId, tag, true, true
+		}
+	}
+	return animalId, tag, false, true
+}
+
+// updateAnimalTags Изменение меток одного животного и ответ с обновлённым животным
+func (t *TagHandler) updateAnimalTags(c *gin.Context, animalTagsInput *input.AnimalTags, status int) {
+	_, httpErr := t.tagService.UpdateAnimalTags(animalTagsInput)
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	animal, httpErr := t.animalService.Get(animalTagsInput.AnimalIds[0])
+	if httpErr != nil {
+		c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+		return
+	}
+
+	c.JSON(status, animal)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"it-planet-task/pkg/paginator"
+	"strconv"
+	"strings"
+)
+
+// TotalCountHeader Заголовок с общим количеством найденных записей для постраничного вывода
+const TotalCountHeader = "X-Total-Count"
+
+// setPageHeaders Передача сведений о странице в заголовках: ссылки на соседние страницы в Link (rel="next", rel="prev")
+// и общее количество записей в X-Total-Count, если оно было посчитано
+func setPageHeaders(c *gin.Context, page *paginator.Page) {
+	if page == nil {
+		return
+	}
+
+	links := make([]string, 0, 2)
+	if link := pageLink(c, page.Next, "after", "next"); link != "" {
+		links = append(links, link)
+	}
+	if link := pageLink(c, page.Prev, "before", "prev"); link != "" {
+		links = append(links, link)
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+
+	if page.Total != nil {
+		c.Header(TotalCountHeader, strconv.FormatInt(*page.Total, 10))
+	}
+}
+
+// pageLink Ссылка на текущий запрос, в котором позиция страницы заменена курсором
+func pageLink(c *gin.Context, cursor *paginator.Cursor, param, rel string) string {
+	if cursor == nil {
+		return ""
+	}
+
+	token, err := paginator.EncodeCursor(cursor)
+	if err != nil {
+		return ""
+	}
+
+	q := c.Request.URL.Query()
+	q.Del("from")
+	q.Del("after")
+	q.Del("before")
+	q.Set(param, token)
+
+	return fmt.Sprintf("<%s?%s>; rel=\"%s\"", c.Request.URL.Path, q.Encode(), rel)
+}
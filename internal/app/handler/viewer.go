@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/service/permission"
+)
+
+// hasPermission Проверка права авторизованного аккаунта так же, как в middleware.Require:
+// для прав на данные учитывается роль в активной организации
+func hasPermission(c *gin.Context, permissionService permission.Permission, requiredPermission string) bool {
+	authorizedAccountAny, ok := c.Get("account")
+	if !ok {
+		return false
+	}
+	role := authorizedAccountAny.(*entity.Account).Role
+	if organizationRole, ok := c.Get("organizationRole"); ok && !permission.IsPlatform(requiredPermission) {
+		role = organizationRole.(string)
+	}
+	return permissionService.HasPermission(role, requiredPermission)
+}
+
+// viewer Права авторизованного аккаунта на чувствительные поля ответа
+func viewer(c *gin.Context, permissionService permission.Permission) *mapper.Viewer {
+	accountId := 0
+	if authorizedAccountAny, ok := c.Get("account"); ok {
+		accountId = authorizedAccountAny.(*entity.Account).Id
+	}
+	return mapper.NewViewer(accountId,
+		hasPermission(c, permissionService, permission.LocationsReadExact),
+		hasPermission(c, permissionService, permission.AccountsReadEmail))
+}
+
+// authorizedAccountId Идентификатор авторизованного аккаунта или nil для анонимного запроса
+func authorizedAccountId(c *gin.Context) *int {
+	authorizedAccountAny, ok := c.Get("account")
+	if !ok {
+		return nil
+	}
+	return &authorizedAccountAny.(*entity.Account).Id
+}
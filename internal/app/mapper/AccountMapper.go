@@ -12,6 +12,7 @@ func AccountToAccountResponse(account *entity.Account) *response.Account {
 		LastName:  account.LastName,
 		Email:     account.Email,
 		Role:      account.Role,
+		Status:    account.Status,
 	}
 
 	return r
@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/service/chip"
+)
+
+func AnimalChipToAnimalChipResponse(animalChip *entity.AnimalChip) *response.AnimalChip {
+	r := &response.AnimalChip{
+		Code:          animalChip.Code,
+		ImplantedAt:   animalChip.ImplantedAt,
+		ImplantedById: animalChip.ImplantedById,
+		RemovedAt:     animalChip.RemovedAt,
+	}
+
+	// номера сохраняются только после проверки, поэтому ошибка разбора означает повреждённую запись
+	code, err := chip.Parse(animalChip.Code)
+	if err != nil {
+		return r
+	}
+	r.CountryCode = code.CountryCode
+	r.Country = code.CountryName()
+	r.ManufacturerCode = code.ManufacturerCode
+	r.Manufacturer = code.ManufacturerName()
+	r.NationalId = animalChip.Code[3:]
+	r.Test = code.Test
+
+	return r
+}
+
+func AnimalChipsToAnimalChipResponses(animalChips *[]entity.AnimalChip) *[]response.AnimalChip {
+	rs := make([]response.AnimalChip, 0)
+
+	for _, animalChip := range *animalChips {
+		r := AnimalChipToAnimalChipResponse(&animalChip)
+		rs = append(rs, *r)
+	}
+
+	return &rs
+}
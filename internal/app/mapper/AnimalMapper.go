@@ -4,6 +4,7 @@ import (
 	"it-planet-task/internal/app/model/entity"
 	"it-planet-task/internal/app/model/input"
 	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/service/chip"
 )
 
 func AnimalToAnimalResponse(animal *entity.Animal) *response.Animal {
@@ -20,6 +21,11 @@ func AnimalToAnimalResponse(animal *entity.Animal) *response.Animal {
 		ChippingLocationId: animal.ChippingLocationId,
 		VisitedLocationsId: []int{},
 		DeathDateTime:      animal.DeathDateTime,
+		OrganizationId:     animal.OrganizationId,
+		BirthDateTime:      animal.BirthDateTime,
+		MotherId:           animal.MotherId,
+		FatherId:           animal.FatherId,
+		ChipCode:           animal.ChipCode,
 	}
 
 	for _, visitedLoc := range animal.VisitedLocations {
@@ -28,6 +34,11 @@ func AnimalToAnimalResponse(animal *entity.Animal) *response.Animal {
 
 	for _, animalType := range animal.AnimalTypes {
 		r.AnimalTypesId = append(r.AnimalTypesId, animalType.Id)
+		r.Sensitive = r.Sensitive || animalType.Sensitive
+	}
+
+	for _, tag := range animal.Tags {
+		r.Tags = append(r.Tags, tag.Name)
 	}
 
 	return r
@@ -51,10 +62,17 @@ func AnimalInputToAnimal(input *input.Animal) *entity.Animal {
 		Gender:             *input.Gender,
 		ChipperId:          *input.ChipperId,
 		ChippingLocationId: *input.ChippingLocationId,
+		BirthDateTime:      input.BirthDateTime,
+		MotherId:           input.MotherId,
+		FatherId:           input.FatherId,
 	}
 	if input.LifeStatus != nil {
 		r.LifeStatus = *input.LifeStatus
 	}
+	if input.ChipCode != nil {
+		chipCode := chip.Normalize(*input.ChipCode)
+		r.ChipCode = &chipCode
+	}
 
 	for _, animalTypeId := range input.AnimalTypeIds {
 		r.AnimalTypes = append(r.AnimalTypes, entity.AnimalType{Id: animalTypeId})
@@ -62,3 +80,12 @@ func AnimalInputToAnimal(input *input.Animal) *entity.Animal {
 
 	return r
 }
+
+func AnimalToAnimalLineageResponse(animal *entity.Animal) *response.AnimalLineage {
+	return &response.AnimalLineage{
+		Id:            animal.Id,
+		Gender:        animal.Gender,
+		LifeStatus:    animal.LifeStatus,
+		BirthDateTime: animal.BirthDateTime,
+	}
+}
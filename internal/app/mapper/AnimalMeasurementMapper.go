@@ -0,0 +1,49 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"strings"
+)
+
+func AnimalMeasurementToAnimalMeasurementResponse(animalMeasurement *entity.AnimalMeasurement) *response.AnimalMeasurement {
+	r := &response.AnimalMeasurement{
+		Id:              animalMeasurement.Id,
+		AnimalId:        animalMeasurement.AnimalId,
+		Type:            animalMeasurement.Type,
+		Value:           animalMeasurement.Value,
+		Unit:            animalMeasurement.Unit,
+		MeasuredAt:      animalMeasurement.MeasuredAt,
+		MeasuredById:    animalMeasurement.MeasuredById,
+		LocationPointId: animalMeasurement.LocationPointId,
+	}
+
+	return r
+}
+
+func AnimalMeasurementsToAnimalMeasurementResponses(animalMeasurements *[]entity.AnimalMeasurement) *[]response.AnimalMeasurement {
+	rs := make([]response.AnimalMeasurement, 0)
+
+	for _, animalMeasurement := range *animalMeasurements {
+		r := AnimalMeasurementToAnimalMeasurementResponse(&animalMeasurement)
+		rs = append(rs, *r)
+	}
+
+	return &rs
+}
+
+func AnimalMeasurementInputToAnimalMeasurement(animalMeasurementInput *input.AnimalMeasurement) *entity.AnimalMeasurement {
+	measurementType := strings.ToUpper(*animalMeasurementInput.Type)
+	animalMeasurement := &entity.AnimalMeasurement{
+		Type:            measurementType,
+		Value:           *animalMeasurementInput.Value,
+		Unit:            entity.MeasurementUnits[measurementType],
+		LocationPointId: animalMeasurementInput.LocationPointId,
+	}
+	if animalMeasurementInput.MeasuredAt != nil {
+		animalMeasurement.MeasuredAt = *animalMeasurementInput.MeasuredAt
+	}
+
+	return animalMeasurement
+}
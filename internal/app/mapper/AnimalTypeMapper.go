@@ -7,8 +7,9 @@ import (
 
 func AnimalTypeToAnimalTypeResponse(animalType *entity.AnimalType) *response.AnimalType {
 	r := &response.AnimalType{
-		Id:   animalType.Id,
-		Type: animalType.Type,
+		Id:        animalType.Id,
+		Type:      animalType.Type,
+		Sensitive: animalType.Sensitive,
 	}
 
 	return r
@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"strings"
+)
+
+func ApiKeyToApiKeyResponse(apiKey *entity.ApiKey) *response.ApiKey {
+	r := &response.ApiKey{
+		Id:         apiKey.Id,
+		AccountId:  apiKey.AccountId,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     apiKey.ScopeList(),
+		CreatedAt:  apiKey.CreatedAt,
+		LastUsedAt: apiKey.LastUsedAt,
+		RevokedAt:  apiKey.RevokedAt,
+	}
+
+	return r
+}
+
+func ApiKeysToApiKeyResponses(apiKeys *[]entity.ApiKey) *[]response.ApiKey {
+	rs := make([]response.ApiKey, 0)
+
+	for _, apiKey := range *apiKeys {
+		rs = append(rs, *ApiKeyToApiKeyResponse(&apiKey))
+	}
+
+	return &rs
+}
+
+func ApiKeyInputToApiKey(input *input.ApiKey) *entity.ApiKey {
+	r := &entity.ApiKey{
+		AccountId: *input.AccountId,
+		Name:      *input.Name,
+		Scopes:    strings.Join(input.Scopes, ","),
+	}
+
+	return r
+}
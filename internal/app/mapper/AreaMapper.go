@@ -7,9 +7,10 @@ import (
 
 func AreaToAreaResponse(area *entity.Area) *response.Area {
 	r := response.Area{
-		Id:         area.Id,
-		Name:       area.Name,
-		AreaPoints: *AreaPointsToAreaPointResponses(&area.AreaPoints),
+		Id:             area.Id,
+		Name:           area.Name,
+		AreaPoints:     *AreaPointsToAreaPointResponses(&area.AreaPoints),
+		OrganizationId: area.OrganizationId,
 	}
 
 	return &r
@@ -27,9 +28,10 @@ func AreasToAreaResponses(areas *[]entity.Area) *[]response.Area {
 
 func AreaResponseToArea(areaResponse *response.Area) *entity.Area {
 	r := entity.Area{
-		Id:         areaResponse.Id,
-		Name:       areaResponse.Name,
-		AreaPoints: *AreaPointResponsesToAreaPoints(&areaResponse.AreaPoints),
+		Id:             areaResponse.Id,
+		Name:           areaResponse.Name,
+		AreaPoints:     *AreaPointResponsesToAreaPoints(&areaResponse.AreaPoints),
+		OrganizationId: areaResponse.OrganizationId,
 	}
 
 	return &r
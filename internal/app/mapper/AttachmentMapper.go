@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+)
+
+func AttachmentToAttachmentResponse(attachment *entity.Attachment) *response.Attachment {
+	r := &response.Attachment{
+		Id:           attachment.Id,
+		AnimalId:     attachment.AnimalId,
+		FileName:     attachment.FileName,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		HasThumbnail: attachment.ThumbnailKey != nil,
+		UploadedById: attachment.UploadedById,
+		CreatedAt:    attachment.CreatedAt,
+	}
+
+	return r
+}
+
+func AttachmentsToAttachmentResponses(attachments *[]entity.Attachment) *[]response.Attachment {
+	rs := make([]response.Attachment, 0)
+
+	for _, attachment := range *attachments {
+		r := AttachmentToAttachmentResponse(&attachment)
+		rs = append(rs, *r)
+	}
+
+	return &rs
+}
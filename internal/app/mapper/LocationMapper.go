@@ -7,9 +7,10 @@ import (
 
 func LocationToLocationResponse(location *entity.Location) *response.Location {
 	r := response.Location{
-		Id:        location.Id,
-		Latitude:  location.Latitude,
-		Longitude: location.Longitude,
+		Id:             location.Id,
+		Latitude:       location.Latitude,
+		Longitude:      location.Longitude,
+		OrganizationId: location.OrganizationId,
 	}
 
 	return &r
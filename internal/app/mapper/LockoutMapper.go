@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/service/throttle"
+)
+
+func LockoutToLockoutResponse(lockout *throttle.Lockout) *response.Lockout {
+	r := &response.Lockout{
+		Type:         lockout.Key.Type,
+		Value:        lockout.Key.Value,
+		Failures:     lockout.Failures,
+		BlockedUntil: lockout.BlockedUntil,
+		Locked:       lockout.Locked,
+	}
+
+	return r
+}
+
+func LockoutsToLockoutResponses(lockouts []throttle.Lockout) *[]response.Lockout {
+	rs := make([]response.Lockout, 0)
+
+	for _, lockout := range lockouts {
+		rs = append(rs, *LockoutToLockoutResponse(&lockout))
+	}
+
+	return &rs
+}
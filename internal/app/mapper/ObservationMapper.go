@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"strings"
+)
+
+func ObservationToObservationResponse(observation *entity.Observation) *response.Observation {
+	r := &response.Observation{
+		Id:                     observation.Id,
+		AnimalId:               observation.AnimalId,
+		VisitedLocationPointId: observation.AnimalLocationId,
+		AuthorId:               observation.AuthorId,
+		Text:                   observation.Text,
+		ObservedAt:             observation.ObservedAt,
+		CreatedAt:              observation.CreatedAt,
+		UpdatedAt:              observation.UpdatedAt,
+	}
+
+	return r
+}
+
+func ObservationsToObservationResponses(observations *[]entity.Observation) *[]response.Observation {
+	rs := make([]response.Observation, 0)
+
+	for _, observation := range *observations {
+		r := ObservationToObservationResponse(&observation)
+		rs = append(rs, *r)
+	}
+
+	return &rs
+}
+
+func ObservationInputToObservation(observationInput *input.Observation) *entity.Observation {
+	observation := &entity.Observation{
+		Text:             strings.TrimSpace(*observationInput.Text),
+		AnimalLocationId: observationInput.VisitedLocationPointId,
+	}
+	if observationInput.ObservedAt != nil {
+		observation.ObservedAt = *observationInput.ObservedAt
+	}
+
+	return observation
+}
@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+)
+
+func OrganizationToOrganizationResponse(organization *entity.Organization) *response.Organization {
+	r := &response.Organization{
+		Id:   organization.Id,
+		Name: organization.Name,
+	}
+
+	return r
+}
+
+func OrganizationsToOrganizationResponses(organizations *[]entity.Organization) *[]response.Organization {
+	rs := make([]response.Organization, 0)
+
+	for _, organization := range *organizations {
+		rs = append(rs, *OrganizationToOrganizationResponse(&organization))
+	}
+
+	return &rs
+}
+
+func MembershipToMembershipResponse(membership *entity.Membership) *response.Membership {
+	r := &response.Membership{
+		OrganizationId: membership.OrganizationId,
+		AccountId:      membership.AccountId,
+		Role:           membership.Role,
+	}
+
+	return r
+}
+
+func MembershipsToMembershipResponses(memberships *[]entity.Membership) *[]response.Membership {
+	rs := make([]response.Membership, 0)
+
+	for _, membership := range *memberships {
+		rs = append(rs, *MembershipToMembershipResponse(&membership))
+	}
+
+	return &rs
+}
+
+func AnimalShareToAnimalShareResponse(animalShare *entity.AnimalShare) *response.AnimalShare {
+	r := &response.AnimalShare{
+		AnimalId:       animalShare.AnimalId,
+		OrganizationId: animalShare.OrganizationId,
+		CreatedAt:      animalShare.CreatedAt,
+	}
+
+	return r
+}
+
+func AnimalSharesToAnimalShareResponses(animalShares *[]entity.AnimalShare) *[]response.AnimalShare {
+	rs := make([]response.AnimalShare, 0)
+
+	for _, animalShare := range *animalShares {
+		rs = append(rs, *AnimalShareToAnimalShareResponse(&animalShare))
+	}
+
+	return &rs
+}
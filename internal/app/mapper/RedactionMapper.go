@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/service/geohash"
+	"it-planet-task/pkg/config"
+)
+
+// DefaultGeohashPrecision ячейка geohash из 5 символов, примерно 5x5 км
+const DefaultGeohashPrecision = 5
+
+// Viewer Аккаунт, для которого формируется ответ, и его права на чувствительные поля
+type Viewer struct {
+	AccountId      int
+	ExactLocations bool
+	AccountEmails  bool
+	// GeohashPrecision число символов geohash, до ячейки которого огрубляются координаты
+	GeohashPrecision uint
+}
+
+// NewViewer Конструктор с точностью огрубления из параметра security.redaction.geohashPrecision конфигурационного файла
+func NewViewer(accountId int, exactLocations, accountEmails bool) *Viewer {
+	precision := config.GetConfig().GetUint("security.redaction.geohashPrecision")
+	if precision == 0 || precision > 12 {
+		precision = DefaultGeohashPrecision
+	}
+	return &Viewer{AccountId: accountId, ExactLocations: exactLocations, AccountEmails: accountEmails, GeohashPrecision: precision}
+}
+
+// RedactLocationResponse Огрубление координат точки, связанной с животным охраняемого типа, до центра ячейки geohash
+func RedactLocationResponse(location *response.Location, viewer *Viewer, sensitive bool) *response.Location {
+	if !sensitive || viewer.ExactLocations || location.Latitude == nil || location.Longitude == nil {
+		return location
+	}
+
+	r := *location
+	r.Geohash = geohash.EncodeWithPrecision(*location.Latitude, *location.Longitude, viewer.GeohashPrecision)
+	latitude, longitude := geohash.DecodeCenter(r.Geohash)
+	r.Latitude = &latitude
+	r.Longitude = &longitude
+
+	return &r
+}
+
+// RedactAccountResponse Скрытие email чужого аккаунта
+func RedactAccountResponse(account *response.Account, viewer *Viewer) *response.Account {
+	if viewer.AccountEmails || account.Id == viewer.AccountId {
+		return account
+	}
+
+	r := *account
+	r.Email = ""
+
+	return &r
+}
+
+func RedactAccountResponses(accounts *[]response.Account, viewer *Viewer) *[]response.Account {
+	rs := make([]response.Account, 0, len(*accounts))
+
+	for _, account := range *accounts {
+		rs = append(rs, *RedactAccountResponse(&account, viewer))
+	}
+
+	return &rs
+}
@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+)
+
+func SecurityEventToSecurityEventResponse(securityEvent *entity.SecurityEvent) *response.SecurityEvent {
+	r := &response.SecurityEvent{
+		Id:        securityEvent.Id,
+		AccountId: securityEvent.AccountId,
+		Email:     securityEvent.Email,
+		Type:      securityEvent.Type,
+		Method:    securityEvent.Method,
+		Reason:    securityEvent.Reason,
+		Ip:        securityEvent.Ip,
+		UserAgent: securityEvent.UserAgent,
+		DateTime:  securityEvent.CreatedAt,
+	}
+
+	return r
+}
+
+func SecurityEventsToSecurityEventResponses(securityEvents *[]entity.SecurityEvent) *[]response.SecurityEvent {
+	rs := make([]response.SecurityEvent, 0)
+
+	for _, securityEvent := range *securityEvents {
+		rs = append(rs, *SecurityEventToSecurityEventResponse(&securityEvent))
+	}
+
+	return &rs
+}
@@ -6,6 +6,11 @@ const (
 	AdminRole   = "ADMIN"
 )
 
+const (
+	ActiveStatus   = "ACTIVE"
+	DisabledStatus = "DISABLED"
+)
+
 type Account struct {
 	Id        int    `gorm:"primary_key"`
 	FirstName string `gorm:"not_null"`
@@ -13,4 +18,12 @@ type Account struct {
 	Email     string `gorm:"not_null"`
 	Password  string `gorm:"not_null"`
 	Role      string `gorm:"not_null"`
+	Status    string `gorm:"not_null;default:ACTIVE"`
+	// ExternalId издатель и subject пользователя внешнего провайдера, с которым связан аккаунт
+	ExternalId *string `gorm:"uniqueIndex" json:"-"`
+}
+
+// IsActive Отключённый аккаунт не может аутентифицироваться, но остаётся чиппером своих животных
+func (a *Account) IsActive() bool {
+	return a.Status != DisabledStatus
 }
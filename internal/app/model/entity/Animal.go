@@ -26,7 +26,18 @@ type Animal struct {
 	ChippingLocationId int `gorm:"not_null"`
 	ChippingLocation   Location
 	VisitedLocations   []AnimalLocation
+	Measurements       []AnimalMeasurement `gorm:"constraint:OnDelete:CASCADE"`
 	DeathDateTime      *time.Time
+	OrganizationId     *int `gorm:"index"`
+	// BirthDateTime, MotherId, FatherId происхождение животного, если родители тоже чипированы
+	BirthDateTime *time.Time
+	MotherId      *int `gorm:"index"`
+	FatherId      *int `gorm:"index"`
+	// ChipCode номер текущего чипа ISO 11784, история чипов хранится в AnimalChip
+	ChipCode    *string      `gorm:"uniqueIndex"`
+	Chips       []AnimalChip `gorm:"constraint:OnDelete:CASCADE"`
+	Attachments []Attachment `gorm:"constraint:OnDelete:CASCADE"`
+	Tags        []Tag        `gorm:"many2many:animal_tags;constraint:OnDelete:CASCADE"`
 }
 
 type AnimalLocationForAreaAnalytics struct {
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// AnimalChip Чип, установленный животному. У текущего чипа RemovedAt пустой, его номер продублирован в Animal.ChipCode.
+// Номер чипа не может повторно использоваться ни у какого животного
+type AnimalChip struct {
+	Id            int       `gorm:"primary_key"`
+	AnimalId      int       `gorm:"not_null;index"`
+	Code          string    `gorm:"not_null;index"`
+	ImplantedAt   time.Time `gorm:"not_null"`
+	ImplantedById *int
+	RemovedAt     *time.Time
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+const (
+	WeightMeasurement = "WEIGHT"
+	HeightMeasurement = "HEIGHT"
+	LengthMeasurement = "LENGTH"
+)
+
+const (
+	KilogramUnit = "kg"
+	MeterUnit    = "m"
+)
+
+// MeasurementUnits Единица измерения для каждого типа замера. Значения хранятся только в этих единицах
+var MeasurementUnits = map[string]string{
+	WeightMeasurement: KilogramUnit,
+	HeightMeasurement: MeterUnit,
+	LengthMeasurement: MeterUnit,
+}
+
+// AnimalMeasurement Замер веса, роста или длины животного. Текущие значения животного берутся из последних замеров
+type AnimalMeasurement struct {
+	Id              int       `gorm:"primary_key"`
+	AnimalId        int       `gorm:"not_null;index"`
+	Type            string    `gorm:"not_null"`
+	Value           float32   `gorm:"not_null"`
+	Unit            string    `gorm:"not_null"`
+	MeasuredAt      time.Time `gorm:"not_null;index"`
+	MeasuredById    *int
+	LocationPointId *int
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// AnimalShare Доступ на чтение к животному для другой организации
+type AnimalShare struct {
+	AnimalId       int `gorm:"primaryKey;autoIncrement:false"`
+	OrganizationId int `gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt      time.Time
+}
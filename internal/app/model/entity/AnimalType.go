@@ -3,4 +3,6 @@ package entity
 type AnimalType struct {
 	Id   int    `gorm:"primary_key"`
 	Type string `gorm:"not_null"`
+	// Sensitive животные этого типа охраняются, точные координаты их локаций видны только ролям с правом locations:read-exact
+	Sensitive bool `gorm:"not_null;default:false"`
 }
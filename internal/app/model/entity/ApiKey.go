@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	AnimalsReadScope    = "animals:read"
+	AnimalsWriteScope   = "animals:write"
+	LocationsReadScope  = "locations:read"
+	LocationsWriteScope = "locations:write"
+	AreasReadScope      = "areas:read"
+	AreasWriteScope     = "areas:write"
+	AccountsReadScope   = "accounts:read"
+	AccountsWriteScope  = "accounts:write"
+)
+
+var ApiKeyScopes = []string{
+	AnimalsReadScope, AnimalsWriteScope,
+	LocationsReadScope, LocationsWriteScope,
+	AreasReadScope, AreasWriteScope,
+	AccountsReadScope, AccountsWriteScope,
+}
+
+type ApiKey struct {
+	Id         int `gorm:"primary_key"`
+	AccountId  int `gorm:"not_null;index"`
+	Account    Account
+	Name       string `gorm:"not_null"`
+	Prefix     string `gorm:"not_null"`
+	KeyHash    string `gorm:"not_null;uniqueIndex"`
+	Scopes     string `gorm:"not_null"`
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func (a *ApiKey) ScopeList() []string {
+	if a.Scopes == "" {
+		return []string{}
+	}
+	return strings.Split(a.Scopes, ",")
+}
+
+func (a *ApiKey) HasScope(scope string) bool {
+	for _, s := range a.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,8 @@
 package entity
 
 type Area struct {
-	Id         int         `gorm:"primary_key"`
-	Name       string      `gorm:"not_null"`
-	AreaPoints []AreaPoint `gorm:"constraint:OnDelete:CASCADE"`
+	Id             int         `gorm:"primary_key"`
+	Name           string      `gorm:"not_null"`
+	AreaPoints     []AreaPoint `gorm:"constraint:OnDelete:CASCADE"`
+	OrganizationId *int        `gorm:"index"`
 }
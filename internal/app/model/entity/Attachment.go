@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// AttachmentContentTypes Типы файлов, которые можно прикреплять к животному. Тип определяется по содержимому файла
+var AttachmentContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+// Attachment Фотография или документ животного. Содержимое хранится в blobstore по ключу StorageKey
+type Attachment struct {
+	Id           int    `gorm:"primary_key"`
+	AnimalId     int    `gorm:"not_null;index"`
+	FileName     string `gorm:"not_null"`
+	ContentType  string `gorm:"not_null"`
+	Size         int64  `gorm:"not_null"`
+	StorageKey   string `gorm:"not_null"`
+	ThumbnailKey *string
+	UploadedById *int
+	CreatedAt    time.Time
+}
@@ -1,9 +1,10 @@
 package entity
 
 type Location struct {
-	Id        int      `gorm:"primary_key"`
-	Latitude  *float64 `gorm:"not_null"`
-	Longitude *float64 `gorm:"not_null"`
+	Id             int      `gorm:"primary_key"`
+	Latitude       *float64 `gorm:"not_null"`
+	Longitude      *float64 `gorm:"not_null"`
+	OrganizationId *int     `gorm:"index"`
 }
 
 func NewLocation(id int, latitude *float64, longitude *float64) *Location {
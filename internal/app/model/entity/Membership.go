@@ -0,0 +1,11 @@
+package entity
+
+// Membership Участие аккаунта в организации. Role действует вместо роли аккаунта при работе с данными организации
+type Membership struct {
+	Id             int `gorm:"primary_key"`
+	OrganizationId int `gorm:"not_null;uniqueIndex:idx_membership"`
+	Organization   Organization
+	AccountId      int `gorm:"not_null;uniqueIndex:idx_membership;index"`
+	Account        Account
+	Role           string `gorm:"not_null"`
+}
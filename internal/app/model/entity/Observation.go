@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Конфигурации полнотекстового поиска PostgreSQL, по которым индексируется текст наблюдения
+const (
+	RussianTextSearch = "russian"
+	EnglishTextSearch = "english"
+)
+
+// Observation Заметка о животном или о конкретном посещении точки, например "хромает на левую заднюю лапу"
+type Observation struct {
+	Id               int  `gorm:"primary_key"`
+	AnimalId         int  `gorm:"not_null;index"`
+	AnimalLocationId *int `gorm:"index"`
+	// AnimalLocation при удалении посещения наблюдение остаётся у животного
+	AnimalLocation *AnimalLocation `gorm:"constraint:OnDelete:SET NULL"`
+	AuthorId       *int
+	Text           string    `gorm:"not_null"`
+	ObservedAt     time.Time `gorm:"not_null;index"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	// SearchVector вычисляется базой данных по тексту сразу для русской и английской конфигураций
+	SearchVector string `gorm:"type:tsvector GENERATED ALWAYS AS (to_tsvector('russian', text) || to_tsvector('english', text)) STORED;index:,type:gin;->:false;<-:false"`
+}
@@ -0,0 +1,7 @@
+package entity
+
+// Organization Организация-партнёр. Животные, точки локации и зоны организации видны только её участникам
+type Organization struct {
+	Id   int    `gorm:"primary_key"`
+	Name string `gorm:"not_null;uniqueIndex"`
+}
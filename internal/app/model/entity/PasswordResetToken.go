@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type PasswordResetToken struct {
+	Id        int       `gorm:"primary_key"`
+	AccountId int       `gorm:"not_null;index"`
+	TokenHash string    `gorm:"not_null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not_null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+func (p *PasswordResetToken) IsActive() bool {
+	return p.UsedAt == nil && time.Now().Before(p.ExpiresAt)
+}
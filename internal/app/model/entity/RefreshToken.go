@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type RefreshToken struct {
+	Id        int       `gorm:"primary_key"`
+	AccountId int       `gorm:"not_null;index"`
+	TokenHash string    `gorm:"not_null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not_null"`
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+func (r *RefreshToken) IsActive() bool {
+	return r.RevokedAt == nil && time.Now().Before(r.ExpiresAt)
+}
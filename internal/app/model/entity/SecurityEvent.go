@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+const (
+	AuthSuccessEvent = "AUTH_SUCCESS"
+	AuthFailureEvent = "AUTH_FAILURE"
+)
+
+const (
+	BasicAuthMethod  = "BASIC"
+	BearerAuthMethod = "BEARER"
+	ApiKeyAuthMethod = "API_KEY"
+	LoginAuthMethod  = "LOGIN"
+	OidcAuthMethod   = "OIDC"
+)
+
+// SecurityEvent Запись журнала аутентификаций.
+// Для неудачных попыток аккаунт может быть неизвестен, тогда сохраняется только email
+type SecurityEvent struct {
+	Id        int  `gorm:"primary_key"`
+	AccountId *int `gorm:"index"`
+	Email     string
+	Type      string `gorm:"not_null"`
+	Method    string `gorm:"not_null"`
+	Reason    string
+	Ip        string
+	UserAgent string
+	CreatedAt time.Time `gorm:"index"`
+}
@@ -0,0 +1,10 @@
+package entity
+
+// Tag Произвольная метка для группировки животных, например "2023 river survey".
+// Метка принадлежит организации животного, имя хранится в нормализованном виде.
+// NULL организации в уникальном индексе различны, поэтому имена общих меток уникальны по отдельному частичному индексу
+type Tag struct {
+	Id             int    `gorm:"primary_key"`
+	Name           string `gorm:"not_null;uniqueIndex:idx_tags_name_organization;uniqueIndex:idx_tags_name_global,where:organization_id IS NULL"`
+	OrganizationId *int   `gorm:"uniqueIndex:idx_tags_name_organization"`
+}
@@ -0,0 +1,6 @@
+package input
+
+type AnimalReassignment struct {
+	ChipperId      *int  `json:"chipperId"`
+	DisableAccount *bool `json:"disableAccount"`
+}
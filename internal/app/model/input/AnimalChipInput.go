@@ -0,0 +1,6 @@
+package input
+
+// AnimalChip Новый чип животного. Предыдущий чип сохраняется в истории
+type AnimalChip struct {
+	ChipCode *string `json:"chipCode"`
+}
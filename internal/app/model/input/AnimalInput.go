@@ -1,5 +1,7 @@
 package input
 
+import "time"
+
 type Animal struct {
 	AnimalTypeIds      []int    `json:"animalTypes"`
 	Weight             *float32 `json:"weight"`
@@ -9,6 +11,20 @@ type Animal struct {
 	ChipperId          *int     `json:"chipperId"`
 	ChippingLocationId *int     `json:"chippingLocationId"`
 	LifeStatus         *string  `json:"lifeStatus"`
+	// MotherId, FatherId, BirthDateTime учитываются только при создании животного.
+	// Для изменения происхождения используется AnimalParents
+	MotherId      *int       `json:"motherId"`
+	FatherId      *int       `json:"fatherId"`
+	BirthDateTime *time.Time `json:"birthDateTime"`
+	// ChipCode учитывается только при создании животного, для смены чипа используется AnimalChip
+	ChipCode *string `json:"chipCode"`
+}
+
+// AnimalParents Происхождение животного. Пустое поле удаляет связь с родителем
+type AnimalParents struct {
+	MotherId      *int       `json:"motherId"`
+	FatherId      *int       `json:"fatherId"`
+	BirthDateTime *time.Time `json:"birthDateTime"`
 }
 
 type AnimalTypeUpdate struct {
@@ -20,3 +36,9 @@ type AnimalLocationPointUpdate struct {
 	VisitedLocationPointId *int `json:"visitedLocationPointId"`
 	LocationPointId        *int `json:"locationPointId"`
 }
+
+// AnimalImport Строка массового импорта. В отличие от создания через API, дата чипирования может быть в прошлом
+type AnimalImport struct {
+	Animal
+	ChippingDateTime *time.Time `json:"chippingDateTime"`
+}
@@ -0,0 +1,11 @@
+package input
+
+import "time"
+
+type AnimalMeasurement struct {
+	Type            *string    `json:"type"`
+	Value           *float32   `json:"value"`
+	Unit            *string    `json:"unit"`
+	MeasuredAt      *time.Time `json:"measuredAt"`
+	LocationPointId *int       `json:"locationPointId"`
+}
@@ -0,0 +1,7 @@
+package input
+
+// AnimalType Изменение типа животного. Если sensitive не передан, признак охраняемого типа не меняется
+type AnimalType struct {
+	Type      string `json:"type"`
+	Sensitive *bool  `json:"sensitive"`
+}
@@ -0,0 +1,7 @@
+package input
+
+type ApiKey struct {
+	AccountId *int     `json:"accountId"`
+	Name      *string  `json:"name"`
+	Scopes    []string `json:"scopes"`
+}
@@ -0,0 +1,24 @@
+package input
+
+type Login struct {
+	Email    *string `json:"email"`
+	Password *string `json:"password"`
+}
+
+type RefreshToken struct {
+	RefreshToken *string `json:"refreshToken"`
+}
+
+type PasswordChange struct {
+	OldPassword *string `json:"oldPassword"`
+	NewPassword *string `json:"newPassword"`
+}
+
+type PasswordResetRequest struct {
+	Email *string `json:"email"`
+}
+
+type PasswordReset struct {
+	Token       *string `json:"token"`
+	NewPassword *string `json:"newPassword"`
+}
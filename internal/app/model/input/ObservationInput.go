@@ -0,0 +1,9 @@
+package input
+
+import "time"
+
+type Observation struct {
+	Text                   *string    `json:"text"`
+	VisitedLocationPointId *int       `json:"visitedLocationPointId"`
+	ObservedAt             *time.Time `json:"observedAt"`
+}
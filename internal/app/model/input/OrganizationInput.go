@@ -0,0 +1,9 @@
+package input
+
+type Organization struct {
+	Name *string `json:"name"`
+}
+
+type Membership struct {
+	Role *string `json:"role"`
+}
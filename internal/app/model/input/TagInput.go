@@ -0,0 +1,8 @@
+package input
+
+// AnimalTags Добавление и удаление меток сразу у группы животных
+type AnimalTags struct {
+	AnimalIds []int    `json:"animalIds"`
+	Add       []string `json:"add"`
+	Remove    []string `json:"remove"`
+}
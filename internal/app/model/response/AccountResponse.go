@@ -4,6 +4,7 @@ type Account struct {
 	Id        int    `json:"id"`
 	FirstName string `json:"firstName"`
 	LastName  string `json:"lastName"`
-	Email     string `json:"email"`
+	Email     string `json:"email,omitempty"`
 	Role      string `json:"role"`
+	Status    string `json:"status"`
 }
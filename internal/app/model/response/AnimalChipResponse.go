@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type AnimalChip struct {
+	Code             string     `json:"code"`
+	CountryCode      *int       `json:"countryCode,omitempty"`
+	Country          string     `json:"country,omitempty"`
+	ManufacturerCode *int       `json:"manufacturerCode,omitempty"`
+	Manufacturer     string     `json:"manufacturer,omitempty"`
+	NationalId       string     `json:"nationalId"`
+	Test             bool       `json:"test,omitempty"`
+	ImplantedAt      time.Time  `json:"implantedAt"`
+	ImplantedById    *int       `json:"implantedById"`
+	RemovedAt        *time.Time `json:"removedAt"`
+}
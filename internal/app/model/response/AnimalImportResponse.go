@@ -0,0 +1,28 @@
+package response
+
+// Состояния строки импорта
+const (
+	ImportRowCreated = "CREATED"
+	ImportRowValid   = "VALID"
+	ImportRowFailed  = "FAILED"
+	// ImportRowSkipped корректная строка, не созданная из-за ошибок в других строках
+	ImportRowSkipped = "SKIPPED"
+)
+
+// AnimalImport Отчёт об импорте животных
+type AnimalImport struct {
+	Format  string            `json:"format"`
+	Mode    string            `json:"mode"`
+	DryRun  bool              `json:"dryRun"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Rows    []AnimalImportRow `json:"rows"`
+}
+
+type AnimalImportRow struct {
+	Line     int      `json:"line"`
+	Status   string   `json:"status"`
+	AnimalId *int     `json:"animalId,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+type AnimalMeasurement struct {
+	Id              int       `json:"id"`
+	AnimalId        int       `json:"animalId"`
+	Type            string    `json:"type"`
+	Value           float32   `json:"value"`
+	Unit            string    `json:"unit"`
+	MeasuredAt      time.Time `json:"measuredAt"`
+	MeasuredById    *int      `json:"measuredById"`
+	LocationPointId *int      `json:"locationPointId"`
+}
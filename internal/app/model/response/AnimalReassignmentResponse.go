@@ -0,0 +1,8 @@
+package response
+
+type AnimalReassignment struct {
+	FromChipperId   int   `json:"fromChipperId"`
+	ToChipperId     int   `json:"toChipperId"`
+	AnimalsCount    int64 `json:"animalsCount"`
+	AccountDisabled bool  `json:"accountDisabled"`
+}
@@ -15,6 +15,25 @@ type Animal struct {
 	ChippingLocationId int        `json:"chippingLocationId"`
 	VisitedLocationsId []int      `json:"visitedLocations"`
 	DeathDateTime      *time.Time `json:"deathDateTime"`
+	OrganizationId     *int       `json:"organizationId,omitempty"`
+	// Sensitive у животного есть охраняемый тип, координаты его локаций могут быть огрублены
+	Sensitive     bool       `json:"sensitive,omitempty"`
+	BirthDateTime *time.Time `json:"birthDateTime,omitempty"`
+	MotherId      *int       `json:"motherId,omitempty"`
+	FatherId      *int       `json:"fatherId,omitempty"`
+	ChipCode      *string    `json:"chipCode,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+}
+
+// AnimalLineage Узел родословной: предки животного в Mother и Father, потомки в Offspring
+type AnimalLineage struct {
+	Id            int             `json:"id"`
+	Gender        string          `json:"gender"`
+	LifeStatus    string          `json:"lifeStatus"`
+	BirthDateTime *time.Time      `json:"birthDateTime,omitempty"`
+	Mother        *AnimalLineage  `json:"mother,omitempty"`
+	Father        *AnimalLineage  `json:"father,omitempty"`
+	Offspring     []AnimalLineage `json:"offspring,omitempty"`
 }
 
 type AnimalForAreaAnalyticsDTO struct {
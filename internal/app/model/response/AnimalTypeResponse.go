@@ -1,6 +1,7 @@
 package response
 
 type AnimalType struct {
-	Id   int    `json:"id"`
-	Type string `json:"type"`
+	Id        int    `json:"id"`
+	Type      string `json:"type"`
+	Sensitive bool   `json:"sensitive"`
 }
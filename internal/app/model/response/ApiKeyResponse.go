@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type ApiKey struct {
+	Id         int        `json:"id"`
+	AccountId  int        `json:"accountId"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+}
+
+// ApiKeyWithSecret Ключ вместе с секретом, который возвращается только при создании и ротации
+type ApiKeyWithSecret struct {
+	ApiKey
+	Key string `json:"key"`
+}
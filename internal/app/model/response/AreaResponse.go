@@ -1,7 +1,8 @@
 package response
 
 type Area struct {
-	Id         int         `json:"id"`
-	Name       string      `json:"name"`
-	AreaPoints []AreaPoint `json:"areaPoints"`
+	Id             int         `json:"id"`
+	Name           string      `json:"name"`
+	AreaPoints     []AreaPoint `json:"areaPoints"`
+	OrganizationId *int        `json:"organizationId,omitempty"`
 }
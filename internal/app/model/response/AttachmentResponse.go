@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+type Attachment struct {
+	Id           int       `json:"id"`
+	AnimalId     int       `json:"animalId"`
+	FileName     string    `json:"fileName"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	HasThumbnail bool      `json:"hasThumbnail"`
+	UploadedById *int      `json:"uploadedById"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
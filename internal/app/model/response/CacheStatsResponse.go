@@ -0,0 +1,9 @@
+package response
+
+type CacheStats struct {
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	Size      int     `json:"size"`
+	HitRatio  float64 `json:"hitRatio"`
+}
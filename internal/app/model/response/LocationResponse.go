@@ -1,7 +1,10 @@
 package response
 
 type Location struct {
-	Id        int      `json:"id"`
-	Latitude  *float64 `json:"latitude"`
-	Longitude *float64 `json:"longitude"`
+	Id             int      `json:"id"`
+	Latitude       *float64 `json:"latitude"`
+	Longitude      *float64 `json:"longitude"`
+	OrganizationId *int     `json:"organizationId,omitempty"`
+	// Geohash ячейка, до центра которой огрублены координаты. Пустая, если координаты точные
+	Geohash string `json:"geohash,omitempty"`
 }
@@ -0,0 +1,11 @@
+package response
+
+import "time"
+
+type Lockout struct {
+	Type         string    `json:"type"`
+	Value        string    `json:"value"`
+	Failures     int       `json:"failures"`
+	BlockedUntil time.Time `json:"blockedUntil"`
+	Locked       bool      `json:"locked"`
+}
@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+type Observation struct {
+	Id                     int       `json:"id"`
+	AnimalId               int       `json:"animalId"`
+	VisitedLocationPointId *int      `json:"visitedLocationPointId"`
+	AuthorId               *int      `json:"authorId"`
+	Text                   string    `json:"text"`
+	ObservedAt             time.Time `json:"observedAt"`
+	CreatedAt              time.Time `json:"createdAt"`
+	UpdatedAt              time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,20 @@
+package response
+
+import "time"
+
+type Organization struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Membership struct {
+	OrganizationId int    `json:"organizationId"`
+	AccountId      int    `json:"accountId"`
+	Role           string `json:"role"`
+}
+
+type AnimalShare struct {
+	AnimalId       int       `json:"animalId"`
+	OrganizationId int       `json:"organizationId"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
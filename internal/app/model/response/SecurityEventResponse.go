@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+type SecurityEvent struct {
+	Id        int       `json:"id"`
+	AccountId *int      `json:"accountId"`
+	Email     string    `json:"email"`
+	Type      string    `json:"type"`
+	Method    string    `json:"method"`
+	Reason    string    `json:"reason,omitempty"`
+	Ip        string    `json:"ip"`
+	UserAgent string    `json:"userAgent"`
+	DateTime  time.Time `json:"dateTime"`
+}
@@ -0,0 +1,16 @@
+package response
+
+type Tag struct {
+	Id             int    `json:"id"`
+	Name           string `json:"name"`
+	OrganizationId *int   `json:"organizationId,omitempty"`
+	// AnimalsCount число животных с меткой
+	AnimalsCount int64 `json:"animalsCount"`
+}
+
+// AnimalTags Результат изменения меток группы животных с нормализованными именами меток
+type AnimalTags struct {
+	AnimalIds []int    `json:"animalIds"`
+	Add       []string `json:"add"`
+	Remove    []string `json:"remove"`
+}
@@ -10,11 +10,15 @@ import (
 type Account interface {
 	Get(id int) (*entity.Account, error)
 	Update(account *entity.Account) (*entity.Account, error)
-	Search(params *filter.AccountFilterParams) (*[]entity.Account, error)
+	Search(params *filter.AccountFilterParams) (*[]entity.Account, *paginator.Page, error)
 	GetByEmail(account *entity.Account) *entity.Account
-	GetByCreds(account *entity.Account) *entity.Account
-	Delete(id int) error
+	GetByExternalId(externalId string) (*entity.Account, error)
+	UpdateExternalIdentity(id int, externalId, role string) error
+	UpdateStatus(id int, status string) error
+	ReassignAnimals(fromAccountId, toAccountId int, disable bool) (int64, error)
 	Create(account *entity.Account) (*entity.Account, error)
+	UpdatePassword(id int, password string) error
+	GetAll() (*[]entity.Account, error)
 }
 
 type AccountRepository struct {
@@ -35,18 +39,15 @@ func (a *AccountRepository) Get(id int) (*entity.Account, error) {
 	return &account, nil
 }
 
-func (a *AccountRepository) Search(params *filter.AccountFilterParams) (*[]entity.Account, error) {
+// Search Поиск аккаунтов. Кроме страницы возвращаются курсоры соседних страниц и общее количество найденных аккаунтов
+func (a *AccountRepository) Search(params *filter.AccountFilterParams) (*[]entity.Account, *paginator.Page, error) {
 	var accounts []entity.Account
-	err := a.Db.
-		Scopes(paginator.Paginate(params),
-			filter.AccountFilter(params)).
-		Order("id").
-		Find(&accounts).Error
+	page, err := paginator.Find(a.Db.Scopes(filter.AccountFilter(params)), params, &accounts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return &accounts, nil
+	return &accounts, page, nil
 }
 
 func (a *AccountRepository) GetByEmail(account *entity.Account) *entity.Account {
@@ -55,10 +56,25 @@ func (a *AccountRepository) GetByEmail(account *entity.Account) *entity.Account
 	return ac
 }
 
-func (a *AccountRepository) GetByCreds(account *entity.Account) *entity.Account {
-	var acc entity.Account
-	a.Db.Where("email = ? AND password = ?", account.Email, account.Password).First(&acc)
-	return &acc
+func (a *AccountRepository) GetByExternalId(externalId string) (*entity.Account, error) {
+	var account entity.Account
+	err := a.Db.Where("external_id = ?", externalId).First(&account).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// UpdateExternalIdentity Связывание аккаунта с пользователем внешнего провайдера и установка роли
+func (a *AccountRepository) UpdateExternalIdentity(id int, externalId, role string) error {
+	err := a.Db.Model(&entity.Account{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"external_id": externalId, "role": role}).Error
+	if err != nil {
+		return err
+	}
+	return nil
 }
 
 func (a *AccountRepository) Update(account *entity.Account) (*entity.Account, error) {
@@ -70,14 +86,47 @@ func (a *AccountRepository) Update(account *entity.Account) (*entity.Account, er
 	return account, nil
 }
 
-func (a *AccountRepository) Delete(id int) error {
-	err := a.Db.Delete(&entity.Account{}, id).Error
+func (a *AccountRepository) UpdateStatus(id int, status string) error {
+	err := a.Db.Model(&entity.Account{}).
+		Where("id = ?", id).
+		Update("status", status).Error
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// ReassignAnimals Передача всех животных одного чиппера другому в одной транзакции.
+// Если disable = true, то в той же транзакции исходный аккаунт отключается
+func (a *AccountRepository) ReassignAnimals(fromAccountId, toAccountId int, disable bool) (int64, error) {
+	var reassigned int64
+	err := a.Db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Animal{}).
+			Where("chipper_id = ?", fromAccountId).
+			Update("chipper_id", toAccountId)
+		if result.Error != nil {
+			return result.Error
+		}
+		reassigned = result.RowsAffected
+
+		if disable {
+			err := tx.Model(&entity.Account{}).
+				Where("id = ?", fromAccountId).
+				Update("status", entity.DisabledStatus).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return reassigned, nil
+}
+
 func (a *AccountRepository) Create(account *entity.Account) (*entity.Account, error) {
 	err := a.Db.Create(&account).Error
 
@@ -87,3 +136,23 @@ func (a *AccountRepository) Create(account *entity.Account) (*entity.Account, er
 
 	return account, nil
 }
+
+func (a *AccountRepository) UpdatePassword(id int, password string) error {
+	err := a.Db.Model(&entity.Account{}).
+		Where("id = ?", id).
+		Update("password", password).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *AccountRepository) GetAll() (*[]entity.Account, error) {
+	var accounts []entity.Account
+	err := a.Db.Order("id").Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &accounts, nil
+}
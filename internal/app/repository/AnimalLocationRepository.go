@@ -9,7 +9,8 @@ import (
 )
 
 type AnimalLocation interface {
-	GetAnimalLocations(animalId int, params *filter.AnimalLocationFilterParams) (*[]entity.AnimalLocation, error)
+	GetAnimalLocations(animalId int, params *filter.AnimalLocationFilterParams) (*[]entity.AnimalLocation, *paginator.Page, error)
+	GetAnimalLocationsInBatches(animalId int, params *filter.AnimalLocationFilterParams, batchSize int, fn func(animalLocations []entity.AnimalLocation) error) error
 	AddAnimalLocationPoint(newAnimalLocation *entity.AnimalLocation) (*entity.AnimalLocation, error)
 	EditAnimalLocationPoint(visitedLocationPointId int, locationPointId int) (*entity.AnimalLocation, error)
 	DeleteAnimalLocationPoint(id int) error
@@ -26,19 +27,25 @@ func NewAnimalLocationRepository(db *gorm.DB, animalRepository Animal) AnimalLoc
 	return &AnimalLocationRepository{Db: db, animalRepository: animalRepository}
 }
 
-func (a *AnimalLocationRepository) GetAnimalLocations(animalId int, params *filter.AnimalLocationFilterParams) (*[]entity.AnimalLocation, error) {
+func (a *AnimalLocationRepository) GetAnimalLocations(animalId int, params *filter.AnimalLocationFilterParams) (*[]entity.AnimalLocation, *paginator.Page, error) {
 	var animalLocations []entity.AnimalLocation
 
-	err := a.Db.Where("animal_id = ?", animalId).
-		Scopes(paginator.Paginate(params),
-			filter.AnimalLocationFilter(params)).
-		Find(&animalLocations).Error
-
+	query := a.Db.Where("animal_id = ?", animalId).
+		Scopes(filter.AnimalLocationFilter(params))
+	page, err := paginator.Find(query, params, &animalLocations)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return &animalLocations, nil
+	return &animalLocations, page, nil
+}
+
+// GetAnimalLocationsInBatches Обход посещённых животным точек страницами по batchSize записей вместе с координатами точек
+func (a *AnimalLocationRepository) GetAnimalLocationsInBatches(animalId int, params *filter.AnimalLocationFilterParams, batchSize int, fn func(animalLocations []entity.AnimalLocation) error) error {
+	query := a.Db.Where("animal_id = ?", animalId).
+		Preload("LocationPoint").
+		Scopes(filter.AnimalLocationFilter(params))
+	return paginator.FindInBatches(query, params, batchSize, fn)
 }
 
 func (a *AnimalLocationRepository) SearchForAreaAnalytics(params *filter.AreaAnalyticsFilterParams) (*[]entity.AnimalLocationForAreaAnalytics, error) {
@@ -103,8 +110,8 @@ func (a *AnimalLocationRepository) SearchForAreaAnalytics(params *filter.AreaAna
 		return nil, err
 	}
 
-	// получаем всех животных, т.к. предыдущий запрос не учитывает точки чипирования
-	animals, err := a.animalRepository.Search(nil)
+	// получаем всех доступных животных, т.к. предыдущий запрос не учитывает точки чипирования
+	animals, err := a.animalRepository.GetAll(params.Tenant)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +123,9 @@ func (a *AnimalLocationRepository) SearchForAreaAnalytics(params *filter.AreaAna
 
 	// заполняем сущности для дальнейшей обработки
 	for _, analyticsDTO := range animalLocationsAnalyticsDTO {
+		if _, ok := animalsMap[analyticsDTO.AnimalId]; !ok {
+			continue
+		}
 		location := entity.NewLocation(0, analyticsDTO.Latitude, analyticsDTO.Longitude)
 		animalLocationForAreaAnalytics := entity.NewAnimalLocationForAreaAnalytics(analyticsDTO.DateTimeOfVisitLocationPoint, *location,
 			animalsMap[analyticsDTO.AnimalId], analyticsDTO.IsPrevious)
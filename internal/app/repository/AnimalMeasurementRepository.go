@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"fmt"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/paginator"
+)
+
+// measurementColumns Столбцы животного, в которых хранится текущее значение замера
+var measurementColumns = map[string]string{
+	entity.WeightMeasurement: "weight",
+	entity.HeightMeasurement: "height",
+	entity.LengthMeasurement: "length",
+}
+
+type AnimalMeasurement interface {
+	Search(animalId int, params *filter.AnimalMeasurementFilterParams) (*[]entity.AnimalMeasurement, *paginator.Page, error)
+	Create(animalMeasurements *[]entity.AnimalMeasurement) (*[]entity.AnimalMeasurement, error)
+}
+
+type AnimalMeasurementRepository struct {
+	Db *gorm.DB
+}
+
+func NewAnimalMeasurementRepository(db *gorm.DB) AnimalMeasurement {
+	return &AnimalMeasurementRepository{Db: db}
+}
+
+func (a *AnimalMeasurementRepository) Search(animalId int, params *filter.AnimalMeasurementFilterParams) (*[]entity.AnimalMeasurement, *paginator.Page, error) {
+	var animalMeasurements []entity.AnimalMeasurement
+	query := a.Db.
+		Where("animal_id = ?", animalId).
+		Scopes(filter.AnimalMeasurementFilter(params))
+	page, err := paginator.Find(query, params, &animalMeasurements)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &animalMeasurements, page, nil
+}
+
+// Create Сохранение замеров и пересчёт текущих значений животного по последним замерам.
+// Замер задним числом не меняет текущее значение, если есть более поздний замер того же типа
+func (a *AnimalMeasurementRepository) Create(animalMeasurements *[]entity.AnimalMeasurement) (*[]entity.AnimalMeasurement, error) {
+	if len(*animalMeasurements) == 0 {
+		return animalMeasurements, nil
+	}
+
+	err := a.Db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(animalMeasurements).Error
+		if err != nil {
+			return err
+		}
+
+		for _, animalMeasurement := range *animalMeasurements {
+			column := measurementColumns[animalMeasurement.Type]
+			err = tx.Exec(fmt.Sprintf(`UPDATE animals SET %s = (
+				SELECT value FROM animal_measurements
+				WHERE animal_id = ? AND type = ?
+				ORDER BY measured_at DESC, id DESC
+				LIMIT 1
+			) WHERE id = ?`, column), animalMeasurement.AnimalId, animalMeasurement.Type, animalMeasurement.AnimalId).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return animalMeasurements, nil
+}
@@ -1,28 +1,53 @@
 package repository
 
 import (
+	"errors"
+	"fmt"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"it-planet-task/internal/app/filter"
 	"it-planet-task/internal/app/model/entity"
 	"it-planet-task/internal/app/model/input"
 	"it-planet-task/pkg/paginator"
+	"time"
 )
 
 type Animal interface {
 	Get(id int) (*entity.Animal, error)
 	GetByIds(ids *[]int) (*[]entity.Animal, error)
-	Search(params *filter.AnimalFilterParams) (*[]entity.Animal, error)
+	GetOrganizationsByIds(ids []int, scope *filter.TenantScope) (*[]entity.Animal, error)
+	Search(params *filter.AnimalFilterParams) (*[]entity.Animal, *paginator.Page, error)
+	SearchInBatches(params *filter.AnimalFilterParams, batchSize int, fn func(animals []entity.Animal) error) error
+	GetAll(scope *filter.TenantScope) (*[]entity.Animal, error)
 	GetAnimalsByAccountId(accountId int) (*[]entity.Animal, error)
 	GetAnimalsByAnimalTypeId(accountId int) (*[]entity.Animal, error)
 	GetAnimalsByLocationId(locationId int) (*[]entity.Animal, error)
 	Create(animal *entity.Animal) (*entity.Animal, error)
 	Update(animal *entity.Animal) (*entity.Animal, error)
-	Delete(id int) error
+	Delete(id int) (*[]entity.Attachment, error)
 	AddAnimalType(animalId, typeId int) (*entity.Animal, error)
 	EditAnimalType(animalId int, input *input.AnimalTypeUpdate) (*entity.Animal, error)
 	DeleteAnimalType(animalId int, typeId int) (*entity.Animal, error)
+	GetShares(animalId int) (*[]entity.AnimalShare, error)
+	IsSharedWith(animalId, organizationId int) bool
+	Share(animalShare *entity.AnimalShare) (*entity.AnimalShare, error)
+	Unshare(animalId, organizationId int) error
+	SetParents(animalId int, parents *input.AnimalParents) (*entity.Animal, error)
+	GetAncestors(animalId, depth int, scope *filter.TenantScope) (*[]entity.Animal, error)
+	GetDescendants(animalId, depth int, scope *filter.TenantScope) (*[]entity.Animal, error)
+	IsDescendant(animalId, candidateId int) bool
+	HasOffspring(animalId int) bool
+	HasOffspringBornAfter(animalId int, dateTime time.Time) bool
+	GetByChipCode(code string) (*entity.Animal, error)
+	GetChips(animalId int) (*[]entity.AnimalChip, error)
+	IsChipRegistered(code string) bool
+	Rechip(animalId int, animalChip *entity.AnimalChip) (*entity.Animal, error)
+	CreateMany(animals []*entity.Animal, atomic bool) []error
 }
 
+// ErrRolledBack животное не создано, потому что транзакция импорта отменена из-за ошибки другого животного
+var ErrRolledBack = errors.New("rolled back because of another row")
+
 type AnimalRepository struct {
 	Db *gorm.DB
 }
@@ -36,6 +61,7 @@ func (a *AnimalRepository) Get(id int) (*entity.Animal, error) {
 	err := a.Db.
 		Preload("VisitedLocations").
 		Preload("AnimalTypes").
+		Preload("Tags").
 		First(&animal, id).Error
 
 	if err != nil {
@@ -60,17 +86,58 @@ func (a *AnimalRepository) GetByIds(ids *[]int) (*[]entity.Animal, error) {
 	return &animals, nil
 }
 
-func (a *AnimalRepository) Search(params *filter.AnimalFilterParams) (*[]entity.Animal, error) {
+// GetOrganizationsByIds Идентификаторы и организации животных из ids, доступных в рамках scope, одним запросом
+func (a *AnimalRepository) GetOrganizationsByIds(ids []int, scope *filter.TenantScope) (*[]entity.Animal, error) {
+	var animals []entity.Animal
+	err := a.Db.
+		Select("id, organization_id").
+		Where("id IN ?", ids).
+		Scopes(filter.AnimalTenantFilter(scope)).
+		Find(&animals).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &animals, nil
+}
+
+func (a *AnimalRepository) Search(params *filter.AnimalFilterParams) (*[]entity.Animal, *paginator.Page, error) {
 	var animals []entity.Animal
 	query := a.Db.
-		Order("id").
 		Preload("AnimalTypes").
+		Preload("Tags").
 		Preload("VisitedLocations").
-		Preload("ChippingLocation")
-	if params != nil {
-		query = query.Scopes(paginator.Paginate(params), filter.AnimalFilter(params))
+		Preload("ChippingLocation").
+		Scopes(filter.AnimalFilter(params))
+	page, err := paginator.Find(query, params, &animals)
+	if err != nil {
+		return nil, nil, err
 	}
-	err := query.
+
+	return &animals, page, nil
+}
+
+// SearchInBatches Обход всех животных, подходящих под фильтр, страницами по batchSize животных
+func (a *AnimalRepository) SearchInBatches(params *filter.AnimalFilterParams, batchSize int, fn func(animals []entity.Animal) error) error {
+	query := a.Db.
+		Preload("AnimalTypes").
+		Preload("Tags").
+		Preload("VisitedLocations").
+		Preload("ChippingLocation").
+		Scopes(filter.AnimalFilter(params))
+	return paginator.FindInBatches(query, params, batchSize, fn)
+}
+
+// GetAll Получение всех животных, доступных в рамках scope, без пагинации
+func (a *AnimalRepository) GetAll(scope *filter.TenantScope) (*[]entity.Animal, error) {
+	var animals []entity.Animal
+	err := a.Db.
+		Order("id").
+		Preload("AnimalTypes").
+		Preload("VisitedLocations").
+		Preload("ChippingLocation").
+		Scopes(filter.AnimalTenantFilter(scope)).
 		Find(&animals).
 		Error
 	if err != nil {
@@ -119,7 +186,9 @@ func (a *AnimalRepository) GetAnimalsByLocationId(locationId int) (*[]entity.Ani
 }
 
 func (a *AnimalRepository) Create(animal *entity.Animal) (*entity.Animal, error) {
-	err := a.Db.Create(&animal).Error
+	err := a.Db.Transaction(func(tx *gorm.DB) error {
+		return createAnimal(tx, animal)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +196,52 @@ func (a *AnimalRepository) Create(animal *entity.Animal) (*entity.Animal, error)
 	return a.Get(animal.Id)
 }
 
+// CreateMany Создание животных при импорте. В режиме atomic все животные создаются в одной транзакции,
+// и ошибка любого из них отменяет создание остальных. Ошибки возвращаются по индексам animals
+func (a *AnimalRepository) CreateMany(animals []*entity.Animal, atomic bool) []error {
+	errs := make([]error, len(animals))
+	if !atomic {
+		for i, animal := range animals {
+			errs[i] = a.Db.Transaction(func(tx *gorm.DB) error {
+				return createAnimal(tx, animal)
+			})
+		}
+		return errs
+	}
+
+	err := a.Db.Transaction(func(tx *gorm.DB) error {
+		for i, animal := range animals {
+			if errs[i] = createAnimal(tx, animal); errs[i] != nil {
+				return errs[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = ErrRolledBack
+			}
+		}
+	}
+	return errs
+}
+
+// createAnimal Создание животного вместе с записью о чипе
+func createAnimal(tx *gorm.DB, animal *entity.Animal) error {
+	err := tx.Create(&animal).Error
+	if err != nil || animal.ChipCode == nil {
+		return err
+	}
+
+	return tx.Create(&entity.AnimalChip{
+		AnimalId:      animal.Id,
+		Code:          *animal.ChipCode,
+		ImplantedAt:   animal.ChippingDateTime,
+		ImplantedById: &animal.ChipperId,
+	}).Error
+}
+
 func (a *AnimalRepository) Update(animal *entity.Animal) (*entity.Animal, error) {
 	err := a.Db.Save(&animal).Error
 	if err != nil {
@@ -136,12 +251,25 @@ func (a *AnimalRepository) Update(animal *entity.Animal) (*entity.Animal, error)
 	return a.Get(animal.Id)
 }
 
-func (a *AnimalRepository) Delete(id int) error {
-	err := a.Db.Delete(&entity.Animal{}, id).Error
+// Delete Удаление животного вместе с записями о вложениях в одной транзакции.
+// Возвращаются удалённые вложения, чтобы удалить их содержимое только после фиксации транзакции
+func (a *AnimalRepository) Delete(id int) (*[]entity.Attachment, error) {
+	var attachments []entity.Attachment
+	err := a.Db.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Returning{}).
+			Where("animal_id = ?", id).
+			Delete(&attachments).Error
+		if err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Animal{}, id).Error
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+
+	return &attachments, nil
 }
 
 func (a *AnimalRepository) AddAnimalType(animalId, typeId int) (*entity.Animal, error) {
@@ -158,3 +286,190 @@ func (a *AnimalRepository) DeleteAnimalType(animalId int, typeId int) (*entity.A
 	a.Db.Exec("DELETE FROM animal_animal_type WHERE animal_id = ? AND animal_type_id = ?", animalId, typeId)
 	return a.Get(animalId)
 }
+
+func (a *AnimalRepository) GetShares(animalId int) (*[]entity.AnimalShare, error) {
+	var animalShares []entity.AnimalShare
+	err := a.Db.
+		Where("animal_id = ?", animalId).
+		Order("organization_id").
+		Find(&animalShares).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &animalShares, nil
+}
+
+func (a *AnimalRepository) IsSharedWith(animalId, organizationId int) bool {
+	var count int64
+	a.Db.Model(&entity.AnimalShare{}).
+		Where("animal_id = ? AND organization_id = ?", animalId, organizationId).
+		Count(&count)
+	return count != 0
+}
+
+func (a *AnimalRepository) Share(animalShare *entity.AnimalShare) (*entity.AnimalShare, error) {
+	err := a.Db.Create(&animalShare).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return animalShare, nil
+}
+
+func (a *AnimalRepository) Unshare(animalId, organizationId int) error {
+	err := a.Db.
+		Where("animal_id = ? AND organization_id = ?", animalId, organizationId).
+		Delete(&entity.AnimalShare{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// lineageColumns Поля животного, нужные для построения родословной
+const lineageColumns = "id, gender, life_status, birth_date_time, mother_id, father_id, organization_id"
+
+// SetParents Изменение происхождения животного. Пустые поля удаляют связь с родителем
+func (a *AnimalRepository) SetParents(animalId int, parents *input.AnimalParents) (*entity.Animal, error) {
+	err := a.Db.Model(&entity.Animal{Id: animalId}).Updates(map[string]interface{}{
+		"mother_id":       parents.MotherId,
+		"father_id":       parents.FatherId,
+		"birth_date_time": parents.BirthDateTime,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Get(animalId)
+}
+
+// GetAncestors Получение животного и его предков до depth поколений
+func (a *AnimalRepository) GetAncestors(animalId, depth int, scope *filter.TenantScope) (*[]entity.Animal, error) {
+	return a.getLineage(animalId, depth, "animals.id IN (lineage.mother_id, lineage.father_id)", scope)
+}
+
+// GetDescendants Получение животного и его потомков до depth поколений
+func (a *AnimalRepository) GetDescendants(animalId, depth int, scope *filter.TenantScope) (*[]entity.Animal, error) {
+	return a.getLineage(animalId, depth, "lineage.id IN (animals.mother_id, animals.father_id)", scope)
+}
+
+// getLineage Обход родословной рекурсивным запросом. Животные недоступных организаций не возвращаются
+func (a *AnimalRepository) getLineage(animalId, depth int, join string, scope *filter.TenantScope) (*[]entity.Animal, error) {
+	var animals []entity.Animal
+	lineage := a.Db.Raw(fmt.Sprintf(`WITH RECURSIVE lineage AS (
+			SELECT id, mother_id, father_id, 0 AS generation FROM animals WHERE id = ?
+			UNION
+			SELECT animals.id, animals.mother_id, animals.father_id, lineage.generation + 1
+			FROM animals JOIN lineage ON %s
+			WHERE lineage.generation < ?
+		)
+		SELECT DISTINCT id FROM lineage`, join), animalId, depth)
+
+	err := a.Db.
+		Select(lineageColumns).
+		Where("id IN (?)", lineage).
+		Scopes(filter.AnimalTenantFilter(scope)).
+		Order("id").
+		Find(&animals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &animals, nil
+}
+
+// IsDescendant Проверка, что candidateId является потомком animalId в любом поколении
+func (a *AnimalRepository) IsDescendant(animalId, candidateId int) bool {
+	var isDescendant bool
+	a.Db.Raw(`WITH RECURSIVE descendants AS (
+			SELECT id FROM animals WHERE mother_id = ? OR father_id = ?
+			UNION
+			SELECT animals.id FROM animals JOIN descendants ON descendants.id IN (animals.mother_id, animals.father_id)
+		)
+		SELECT EXISTS (SELECT 1 FROM descendants WHERE id = ?)`, animalId, animalId, candidateId).Scan(&isDescendant)
+	return isDescendant
+}
+
+func (a *AnimalRepository) HasOffspring(animalId int) bool {
+	var count int64
+	a.Db.Model(&entity.Animal{}).
+		Where("mother_id = ? OR father_id = ?", animalId, animalId).
+		Count(&count)
+	return count != 0
+}
+
+// HasOffspringBornAfter Проверка, что у животного есть потомки с датой рождения позже dateTime
+func (a *AnimalRepository) HasOffspringBornAfter(animalId int, dateTime time.Time) bool {
+	var count int64
+	a.Db.Model(&entity.Animal{}).
+		Where("mother_id = ? OR father_id = ?", animalId, animalId).
+		Where("birth_date_time > ?", dateTime).
+		Count(&count)
+	return count != 0
+}
+
+// GetByChipCode Получение животного по номеру текущего или одного из прежних чипов
+func (a *AnimalRepository) GetByChipCode(code string) (*entity.Animal, error) {
+	var animal entity.Animal
+	err := a.Db.
+		Preload("VisitedLocations").
+		Preload("AnimalTypes").
+		Preload("Tags").
+		Where("chip_code = ? OR id IN (SELECT animal_id FROM animal_chips WHERE code = ?)", code, code).
+		First(&animal).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &animal, nil
+}
+
+func (a *AnimalRepository) GetChips(animalId int) (*[]entity.AnimalChip, error) {
+	var animalChips []entity.AnimalChip
+	err := a.Db.
+		Where("animal_id = ?", animalId).
+		Order("implanted_at DESC, id DESC").
+		Find(&animalChips).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &animalChips, nil
+}
+
+// IsChipRegistered Проверка, что номер чипа уже был установлен какому-либо животному
+func (a *AnimalRepository) IsChipRegistered(code string) bool {
+	var count int64
+	a.Db.Model(&entity.AnimalChip{}).Where("code = ?", code).Count(&count)
+	if count != 0 {
+		return true
+	}
+	a.Db.Model(&entity.Animal{}).Where("chip_code = ?", code).Count(&count)
+	return count != 0
+}
+
+// Rechip Замена чипа животного: текущий чип помечается снятым в момент установки нового
+func (a *AnimalRepository) Rechip(animalId int, animalChip *entity.AnimalChip) (*entity.Animal, error) {
+	animalChip.AnimalId = animalId
+	err := a.Db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.AnimalChip{}).
+			Where("animal_id = ? AND removed_at IS NULL", animalId).
+			Update("removed_at", animalChip.ImplantedAt).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Create(animalChip).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.Animal{Id: animalId}).Update("chip_code", animalChip.Code).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Get(animalId)
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/paginator"
+	"time"
+)
+
+type ApiKey interface {
+	Get(id int) (*entity.ApiKey, error)
+	GetByHash(keyHash string) (*entity.ApiKey, error)
+	Search(params *filter.ApiKeyFilterParams) (*[]entity.ApiKey, *paginator.Page, error)
+	Create(apiKey *entity.ApiKey) (*entity.ApiKey, error)
+	UpdateKey(id int, prefix string, keyHash string) (*entity.ApiKey, error)
+	Revoke(id int) error
+	UpdateLastUsed(id int, lastUsedAt time.Time) error
+}
+
+type ApiKeyRepository struct {
+	Db *gorm.DB
+}
+
+func NewApiKeyRepository(db *gorm.DB) ApiKey {
+	return &ApiKeyRepository{Db: db}
+}
+
+func (a *ApiKeyRepository) Get(id int) (*entity.ApiKey, error) {
+	var apiKey entity.ApiKey
+	err := a.Db.First(&apiKey, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+func (a *ApiKeyRepository) GetByHash(keyHash string) (*entity.ApiKey, error) {
+	var apiKey entity.ApiKey
+	err := a.Db.
+		Preload("Account").
+		Where("key_hash = ? AND revoked_at IS NULL", keyHash).
+		First(&apiKey).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
+
+func (a *ApiKeyRepository) Search(params *filter.ApiKeyFilterParams) (*[]entity.ApiKey, *paginator.Page, error) {
+	var apiKeys []entity.ApiKey
+	page, err := paginator.Find(a.Db.Scopes(filter.ApiKeyFilter(params)), params, &apiKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &apiKeys, page, nil
+}
+
+func (a *ApiKeyRepository) Create(apiKey *entity.ApiKey) (*entity.ApiKey, error) {
+	err := a.Db.Create(&apiKey).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+func (a *ApiKeyRepository) UpdateKey(id int, prefix string, keyHash string) (*entity.ApiKey, error) {
+	err := a.Db.Model(&entity.ApiKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"prefix": prefix, "key_hash": keyHash}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Get(id)
+}
+
+func (a *ApiKeyRepository) Revoke(id int) error {
+	err := a.Db.Model(&entity.ApiKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a *ApiKeyRepository) UpdateLastUsed(id int, lastUsedAt time.Time) error {
+	err := a.Db.Model(&entity.ApiKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", lastUsedAt).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -12,7 +12,8 @@ type Area interface {
 	Create(area *entity.Area) (*entity.Area, error)
 	Update(area *entity.Area) (*entity.Area, error)
 	Delete(id int) error
-	Search(params *filter.AreaFilterParams) (*[]entity.Area, error)
+	Search(params *filter.AreaFilterParams) (*[]entity.Area, *paginator.Page, error)
+	SearchInBatches(params *filter.AreaFilterParams, batchSize int, fn func(areas []entity.Area) error) error
 }
 
 type AreaRepository struct {
@@ -62,24 +63,24 @@ func (a *AreaRepository) Delete(id int) error {
 	return nil
 }
 
-func (a *AreaRepository) Search(params *filter.AreaFilterParams) (*[]entity.Area, error) {
+func (a *AreaRepository) Search(params *filter.AreaFilterParams) (*[]entity.Area, *paginator.Page, error) {
 	var areas []entity.Area
 	query := a.Db.
 		Preload("AreaPoints").
-		Order("id")
-
-	if params != nil {
-		query = query.
-			Scopes(paginator.Paginate(params))
-	}
-
-	err := query.
-		Find(&areas).
-		Error
+		Scopes(filter.TenantFilter("areas", params.Tenant))
 
+	page, err := paginator.Find(query, params, &areas)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return &areas, nil
+	return &areas, page, nil
+}
+
+// SearchInBatches Обход всех доступных зон страницами по batchSize зон
+func (a *AreaRepository) SearchInBatches(params *filter.AreaFilterParams, batchSize int, fn func(areas []entity.Area) error) error {
+	query := a.Db.
+		Preload("AreaPoints").
+		Scopes(filter.TenantFilter("areas", params.Tenant))
+	return paginator.FindInBatches(query, params, batchSize, fn)
 }
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/paginator"
+)
+
+type Attachment interface {
+	Get(id int) (*entity.Attachment, error)
+	Search(animalId int, params *filter.AttachmentFilterParams) (*[]entity.Attachment, *paginator.Page, error)
+	Create(attachment *entity.Attachment) (*entity.Attachment, error)
+	Delete(id int) error
+}
+
+type AttachmentRepository struct {
+	Db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) Attachment {
+	return &AttachmentRepository{Db: db}
+}
+
+func (a *AttachmentRepository) Get(id int) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+	err := a.Db.First(&attachment, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (a *AttachmentRepository) Search(animalId int, params *filter.AttachmentFilterParams) (*[]entity.Attachment, *paginator.Page, error) {
+	var attachments []entity.Attachment
+	query := a.Db.Where("animal_id = ?", animalId)
+	page, err := paginator.Find(query, params, &attachments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &attachments, page, nil
+}
+
+func (a *AttachmentRepository) Create(attachment *entity.Attachment) (*entity.Attachment, error) {
+	err := a.Db.Create(&attachment).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (a *AttachmentRepository) Delete(id int) error {
+	err := a.Db.Delete(&entity.Attachment{}, id).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
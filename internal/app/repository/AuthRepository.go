@@ -3,10 +3,19 @@ package repository
 import (
 	"gorm.io/gorm"
 	"it-planet-task/internal/app/model/entity"
+	"time"
 )
 
 type Auth interface {
 	Register(newAccount *entity.Account) (*entity.Account, error)
+	CreateRefreshToken(refreshToken *entity.RefreshToken) (*entity.RefreshToken, error)
+	GetRefreshTokenByHash(tokenHash string) (*entity.RefreshToken, error)
+	RevokeRefreshToken(id int) (bool, error)
+	RevokeAccountRefreshTokens(accountId int) error
+	CreatePasswordResetToken(resetToken *entity.PasswordResetToken) (*entity.PasswordResetToken, error)
+	GetPasswordResetTokenByHash(tokenHash string) (*entity.PasswordResetToken, error)
+	UsePasswordResetToken(id int) (bool, error)
+	UseAccountPasswordResetTokens(accountId int) error
 }
 
 type AuthRepository struct {
@@ -25,3 +34,85 @@ func (a AuthRepository) Register(newAccount *entity.Account) (*entity.Account, e
 
 	return newAccount, nil
 }
+
+func (a AuthRepository) CreateRefreshToken(refreshToken *entity.RefreshToken) (*entity.RefreshToken, error) {
+	err := a.Db.Create(&refreshToken).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return refreshToken, nil
+}
+
+func (a AuthRepository) GetRefreshTokenByHash(tokenHash string) (*entity.RefreshToken, error) {
+	var refreshToken entity.RefreshToken
+	err := a.Db.Where("token_hash = ?", tokenHash).First(&refreshToken).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &refreshToken, nil
+}
+
+// RevokeRefreshToken Отзыв refresh токена.
+// Возвращает false, если токен уже был отозван параллельным запросом
+func (a AuthRepository) RevokeRefreshToken(id int) (bool, error) {
+	result := a.Db.Model(&entity.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (a AuthRepository) RevokeAccountRefreshTokens(accountId int) error {
+	err := a.Db.Model(&entity.RefreshToken{}).
+		Where("account_id = ? AND revoked_at IS NULL", accountId).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (a AuthRepository) CreatePasswordResetToken(resetToken *entity.PasswordResetToken) (*entity.PasswordResetToken, error) {
+	err := a.Db.Create(&resetToken).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return resetToken, nil
+}
+
+func (a AuthRepository) GetPasswordResetTokenByHash(tokenHash string) (*entity.PasswordResetToken, error) {
+	var resetToken entity.PasswordResetToken
+	err := a.Db.Where("token_hash = ?", tokenHash).First(&resetToken).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &resetToken, nil
+}
+
+// UsePasswordResetToken Пометка токена сброса пароля использованным.
+// Возвращает false, если токен уже был использован параллельным запросом
+func (a AuthRepository) UsePasswordResetToken(id int) (bool, error) {
+	result := a.Db.Model(&entity.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (a AuthRepository) UseAccountPasswordResetTokens(accountId int) error {
+	err := a.Db.Model(&entity.PasswordResetToken{}).
+		Where("account_id = ? AND used_at IS NULL", accountId).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
 	"it-planet-task/internal/app/model/entity"
 )
 
@@ -11,6 +12,9 @@ type Location interface {
 	Update(location *entity.Location) (*entity.Location, error)
 	Delete(id int) error
 	GetByCoordinates(location *entity.Location) (*entity.Location, error)
+	IsSensitive(id int) bool
+	GetSensitiveIds(ids []int) ([]int, error)
+	GetInBoundingBox(minLatitude, maxLatitude, minLongitude, maxLongitude float64) (*[]entity.Location, error)
 }
 
 type LocationRepository struct {
@@ -57,8 +61,67 @@ func (a *LocationRepository) Delete(id int) error {
 	return nil
 }
 
+// GetByCoordinates Поиск точки по координатам среди точек общего пространства и организации location.OrganizationId.
+// Точка организации имеет приоритет над точкой общего пространства
 func (a *LocationRepository) GetByCoordinates(location *entity.Location) (*entity.Location, error) {
 	lc := &entity.Location{}
-	err := a.Db.Where("longitude = ? AND latitude = ?", location.Longitude, location.Latitude).First(lc).Error
+	err := a.Db.
+		Where("longitude = ? AND latitude = ?", location.Longitude, location.Latitude).
+		Scopes(filter.TenantFilter("locations", &filter.TenantScope{OrganizationId: location.OrganizationId})).
+		Order("organization_id IS NULL").
+		First(lc).Error
 	return lc, err
 }
+
+// IsSensitive Проверка, что точку использует животное охраняемого типа: как точку чипирования или как посещённую точку
+func (a *LocationRepository) IsSensitive(id int) bool {
+	var sensitive bool
+	a.Db.Raw(`SELECT EXISTS (
+		SELECT 1 FROM animal_animal_type
+		JOIN animal_types ON animal_types.id = animal_animal_type.animal_type_id AND animal_types.sensitive
+		WHERE animal_animal_type.animal_id IN (
+			SELECT id FROM animals WHERE chipping_location_id = ?
+			UNION
+			SELECT animal_id FROM animal_locations WHERE location_point_id = ?
+		)
+	)`, id, id).Scan(&sensitive)
+	return sensitive
+}
+
+// GetSensitiveIds Отбор из ids точек, которые использует животное охраняемого типа, по правилам IsSensitive
+func (a *LocationRepository) GetSensitiveIds(ids []int) ([]int, error) {
+	sensitiveIds := make([]int, 0)
+	if len(ids) == 0 {
+		return sensitiveIds, nil
+	}
+
+	err := a.Db.Raw(`WITH sensitive_animals AS (
+		SELECT animal_animal_type.animal_id FROM animal_animal_type
+		JOIN animal_types ON animal_types.id = animal_animal_type.animal_type_id AND animal_types.sensitive
+	)
+	SELECT chipping_location_id FROM animals
+	WHERE chipping_location_id IN ? AND id IN (SELECT animal_id FROM sensitive_animals)
+	UNION
+	SELECT location_point_id FROM animal_locations
+	WHERE location_point_id IN ? AND animal_id IN (SELECT animal_id FROM sensitive_animals)`, ids, ids).
+		Scan(&sensitiveIds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sensitiveIds, nil
+}
+
+// GetInBoundingBox Получение точек, попадающих в прямоугольник координат, включая границы
+func (a *LocationRepository) GetInBoundingBox(minLatitude, maxLatitude, minLongitude, maxLongitude float64) (*[]entity.Location, error) {
+	var locations []entity.Location
+	err := a.Db.
+		Where("latitude BETWEEN ? AND ?", minLatitude, maxLatitude).
+		Where("longitude BETWEEN ? AND ?", minLongitude, maxLongitude).
+		Find(&locations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &locations, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/paginator"
+)
+
+type Observation interface {
+	Get(id int) (*entity.Observation, error)
+	Search(params *filter.ObservationFilterParams) (*[]entity.Observation, *paginator.Page, error)
+	Create(observation *entity.Observation) (*entity.Observation, error)
+	Update(observation *entity.Observation) (*entity.Observation, error)
+	Delete(id int) error
+}
+
+type ObservationRepository struct {
+	Db *gorm.DB
+}
+
+func NewObservationRepository(db *gorm.DB) Observation {
+	return &ObservationRepository{Db: db}
+}
+
+func (o *ObservationRepository) Get(id int) (*entity.Observation, error) {
+	var observation entity.Observation
+	err := o.Db.First(&observation, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &observation, nil
+}
+
+func (o *ObservationRepository) Search(params *filter.ObservationFilterParams) (*[]entity.Observation, *paginator.Page, error) {
+	var observations []entity.Observation
+	query := o.Db.Scopes(filter.ObservationFilter(params))
+	page, err := paginator.Find(query, params, &observations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &observations, page, nil
+}
+
+func (o *ObservationRepository) Create(observation *entity.Observation) (*entity.Observation, error) {
+	err := o.Db.Create(&observation).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return o.Get(observation.Id)
+}
+
+func (o *ObservationRepository) Update(observation *entity.Observation) (*entity.Observation, error) {
+	err := o.Db.Model(&entity.Observation{Id: observation.Id}).Updates(map[string]interface{}{
+		"text":               observation.Text,
+		"animal_location_id": observation.AnimalLocationId,
+		"observed_at":        observation.ObservedAt,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return o.Get(observation.Id)
+}
+
+func (o *ObservationRepository) Delete(id int) error {
+	err := o.Db.Delete(&entity.Observation{}, id).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/paginator"
+)
+
+type Organization interface {
+	Get(id int) (*entity.Organization, error)
+	GetByName(name string) *entity.Organization
+	Search(params *filter.OrganizationFilterParams) (*[]entity.Organization, *paginator.Page, error)
+	Create(organization *entity.Organization) (*entity.Organization, error)
+	Update(organization *entity.Organization) (*entity.Organization, error)
+	GetMembership(organizationId, accountId int) (*entity.Membership, error)
+	GetMembers(organizationId int) (*[]entity.Membership, error)
+	SaveMembership(membership *entity.Membership) (*entity.Membership, error)
+	DeleteMembership(organizationId, accountId int) error
+}
+
+type OrganizationRepository struct {
+	Db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) Organization {
+	return &OrganizationRepository{Db: db}
+}
+
+func (o *OrganizationRepository) Get(id int) (*entity.Organization, error) {
+	var organization entity.Organization
+	err := o.Db.First(&organization, id).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &organization, nil
+}
+
+func (o *OrganizationRepository) GetByName(name string) *entity.Organization {
+	organization := &entity.Organization{}
+	o.Db.Where("name = ?", name).First(organization)
+	return organization
+}
+
+func (o *OrganizationRepository) Search(params *filter.OrganizationFilterParams) (*[]entity.Organization, *paginator.Page, error) {
+	var organizations []entity.Organization
+	page, err := paginator.Find(o.Db.Scopes(filter.OrganizationFilter(params)), params, &organizations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &organizations, page, nil
+}
+
+func (o *OrganizationRepository) Create(organization *entity.Organization) (*entity.Organization, error) {
+	err := o.Db.Create(&organization).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (o *OrganizationRepository) Update(organization *entity.Organization) (*entity.Organization, error) {
+	err := o.Db.Save(&organization).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (o *OrganizationRepository) GetMembership(organizationId, accountId int) (*entity.Membership, error) {
+	var membership entity.Membership
+	err := o.Db.
+		Where("organization_id = ? AND account_id = ?", organizationId, accountId).
+		First(&membership).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &membership, nil
+}
+
+func (o *OrganizationRepository) GetMembers(organizationId int) (*[]entity.Membership, error) {
+	var memberships []entity.Membership
+	err := o.Db.
+		Where("organization_id = ?", organizationId).
+		Order("account_id").
+		Find(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &memberships, nil
+}
+
+// SaveMembership Добавление участника в организацию или изменение его роли
+func (o *OrganizationRepository) SaveMembership(membership *entity.Membership) (*entity.Membership, error) {
+	err := o.Db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "organization_id"}, {Name: "account_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"role"}),
+		}).
+		Create(&membership).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return o.GetMembership(membership.OrganizationId, membership.AccountId)
+}
+
+func (o *OrganizationRepository) DeleteMembership(organizationId, accountId int) error {
+	err := o.Db.
+		Where("organization_id = ? AND account_id = ?", organizationId, accountId).
+		Delete(&entity.Membership{}).Error
+	if err != nil {
+		return err
+	}
+	return nil
+}
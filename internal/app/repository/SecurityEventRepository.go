@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/paginator"
+	"time"
+)
+
+type SecurityEvent interface {
+	Create(securityEvent *entity.SecurityEvent) error
+	Search(params *filter.SecurityEventFilterParams) (*[]entity.SecurityEvent, *paginator.Page, error)
+	DeleteBefore(before time.Time) (int64, error)
+}
+
+type SecurityEventRepository struct {
+	Db *gorm.DB
+}
+
+func NewSecurityEventRepository(db *gorm.DB) SecurityEvent {
+	return &SecurityEventRepository{Db: db}
+}
+
+func (s *SecurityEventRepository) Create(securityEvent *entity.SecurityEvent) error {
+	return s.Db.Create(securityEvent).Error
+}
+
+func (s *SecurityEventRepository) Search(params *filter.SecurityEventFilterParams) (*[]entity.SecurityEvent, *paginator.Page, error) {
+	var securityEvents []entity.SecurityEvent
+	page, err := paginator.Find(s.Db.Scopes(filter.SecurityEventFilter(params)), params, &securityEvents)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &securityEvents, page, nil
+}
+
+// DeleteBefore Удаление записей, созданных раньше указанного момента
+func (s *SecurityEventRepository) DeleteBefore(before time.Time) (int64, error) {
+	result := s.Db.Where("created_at < ?", before).Delete(&entity.SecurityEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+)
+
+type Tag interface {
+	Search(params *filter.TagFilterParams) (*[]response.Tag, error)
+	UpdateAnimalTags(animalIds []int, add, remove []string) error
+}
+
+type TagRepository struct {
+	Db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) Tag {
+	return &TagRepository{Db: db}
+}
+
+// Search Подсказки меток, которые есть хотя бы у одного животного. Сначала идут самые используемые метки
+func (t *TagRepository) Search(params *filter.TagFilterParams) (*[]response.Tag, error) {
+	var tags []response.Tag
+	err := t.Db.Model(&entity.Tag{}).
+		Select("tags.id, tags.name, tags.organization_id, COUNT(animal_tags.animal_id) AS animals_count").
+		Joins("JOIN animal_tags ON animal_tags.tag_id = tags.id").
+		Scopes(filter.TagFilter(params)).
+		Group("tags.id").
+		Order("animals_count DESC, tags.name, tags.id").
+		Limit(params.Size).
+		Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &tags, nil
+}
+
+// UpdateAnimalTags Добавление меток add и удаление меток remove у животных в одной транзакции.
+// Недостающие метки создаются в организации каждого животного
+func (t *TagRepository) UpdateAnimalTags(animalIds []int, add, remove []string) error {
+	return t.Db.Transaction(func(tx *gorm.DB) error {
+		if len(remove) > 0 {
+			err := tx.Exec(`DELETE FROM animal_tags WHERE animal_id IN ?
+				AND tag_id IN (SELECT id FROM tags WHERE name IN ?)`, animalIds, remove).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(add) == 0 || len(animalIds) == 0 {
+			return nil
+		}
+
+		var organizationIds []*int
+		err := tx.Model(&entity.Animal{}).
+			Where("id IN ?", animalIds).
+			Distinct().
+			Pluck("organization_id", &organizationIds).Error
+		if err != nil {
+			return err
+		}
+
+		// метки, созданные параллельным запросом, пропускаются уникальными индексами, а затем выбираются по имени
+		tags := make([]entity.Tag, 0, len(organizationIds)*len(add))
+		for _, organizationId := range organizationIds {
+			for _, name := range add {
+				tags = append(tags, entity.Tag{Name: name, OrganizationId: organizationId})
+			}
+		}
+		err = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(`INSERT INTO animal_tags (animal_id, tag_id)
+			SELECT animals.id, tags.id FROM animals
+			JOIN tags ON tags.organization_id IS NOT DISTINCT FROM animals.organization_id
+			WHERE animals.id IN ? AND tags.name IN ?
+			ON CONFLICT DO NOTHING`, animalIds, add).Error
+	})
+}
@@ -4,10 +4,17 @@ import (
 	"github.com/gin-gonic/gin"
 	"it-planet-task/helpers"
 	"it-planet-task/internal/app/handler"
+	"it-planet-task/internal/app/model/entity"
 	"it-planet-task/internal/app/repository"
 	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/blobstore"
 	"it-planet-task/internal/app/service/geometry"
+	"it-planet-task/internal/app/service/notifier"
+	"it-planet-task/internal/app/service/password"
+	"it-planet-task/internal/app/service/permission"
+	"it-planet-task/internal/app/service/token"
 	"it-planet-task/internal/pkg/middleware"
+	"log"
 )
 
 // InitRoutes Инициализация путей эндпоинтов, сервисов и репозиториев
@@ -17,8 +24,18 @@ func InitRoutes(r *gin.Engine) *gin.Engine {
 	animalTypeRepo := repository.NewAnimalTypeRepository(helpers.GetConnectionOrCreateAndGet())
 	animalTypeService := service.NewAnimalTypeService(animalTypeRepo)
 
+	passwordService := password.NewPasswordService(password.NewParamsFromConfig())
+	tokenParams, err := token.NewParamsFromConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	tokenService := token.NewTokenService(tokenParams)
+	if err := middleware.InitOidcService(); err != nil {
+		log.Fatal(err)
+	}
+
 	accountRepo := repository.NewAccountRepository(helpers.GetConnectionOrCreateAndGet())
-	accountService := service.NewAccountService(accountRepo)
+	accountService := service.NewAccountService(accountRepo, passwordService, middleware.GetAccountCache())
 
 	locationRepo := repository.NewLocationRepository(helpers.GetConnectionOrCreateAndGet())
 	locationService := service.NewLocationService(locationRepo)
@@ -32,77 +49,211 @@ func InitRoutes(r *gin.Engine) *gin.Engine {
 	geometryService := geometry.NewGeometryService()
 
 	areaRepo := repository.NewAreaRepository(helpers.GetConnectionOrCreateAndGet())
-	areaService := service.NewAreaService(areaRepo, animalLocationService, geometryService)
+	areaService := service.NewAreaService(areaRepo, animalLocationService, locationService, geometryService)
+
+	animalMeasurementRepo := repository.NewAnimalMeasurementRepository(helpers.GetConnectionOrCreateAndGet())
+	animalMeasurementService := service.NewAnimalMeasurementService(animalMeasurementRepo)
+
+	organizationRepo := repository.NewOrganizationRepository(helpers.GetConnectionOrCreateAndGet())
+	organizationService := service.NewOrganizationService(organizationRepo)
+
+	blobStore, err := blobstore.NewBlobStore(blobstore.NewParamsFromConfig())
+	if err != nil {
+		log.Fatal(err)
+	}
+	attachmentRepo := repository.NewAttachmentRepository(helpers.GetConnectionOrCreateAndGet())
+	attachmentService := service.NewAttachmentService(attachmentRepo, blobStore, service.NewAttachmentParamsFromConfig())
 
-	animalHandler := handler.NewAnimalHandler(animalService, animalTypeService, accountService, locationService, animalLocationService)
+	animalHandler := handler.NewAnimalHandler(animalService, animalTypeService, accountService, locationService, animalLocationService, areaService, organizationService, animalMeasurementService, attachmentService, middleware.GetPermissionService())
 	animalGroup := api.Group("animals")
 	{
-		animalGroup.GET("/:id", middleware.BasicAuth, animalHandler.Get)
-		animalGroup.GET("/search", middleware.BasicAuth, animalHandler.Search)
-		animalGroup.POST("", middleware.BasicAuth, animalHandler.Create)
-		animalGroup.PUT("/:id", middleware.BasicAuth, animalHandler.Update)
-		animalGroup.DELETE("/:id", middleware.BasicAuth, middleware.AdminRequired, animalHandler.Delete)
+		animalGroup.GET("/:id", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), animalHandler.Get)
+		animalGroup.GET("/by-chip/:code", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), animalHandler.GetByChipCode)
+		animalGroup.GET("/search", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), animalHandler.Search)
+		animalGroup.POST("", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsCreate), animalHandler.Create)
+		animalGroup.PUT("/:id", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), animalHandler.Update)
+		animalGroup.DELETE("/:id", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsDelete), animalHandler.Delete)
+
+		animalGroup.POST("/:id/types/:typeId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsEditTypes), animalHandler.AddAnimalType)
+		animalGroup.PUT("/:id/types", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsEditTypes), animalHandler.EditAnimalType)
+		animalGroup.DELETE("/:id/types/:typeId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsEditTypes), animalHandler.DeleteAnimalType)
+
+		animalGroup.PUT("/:id/parents", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), animalHandler.SetParents)
+		animalGroup.GET("/:id/ancestors", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), animalHandler.GetAncestors)
+		animalGroup.GET("/:id/descendants", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), animalHandler.GetDescendants)
+
+		animalGroup.GET("/:id/chips", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), animalHandler.GetChips)
+		animalGroup.PUT("/:id/chip", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsChip), animalHandler.Rechip)
+
+		animalGroup.GET("/:id/shares", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsShare), animalHandler.GetShares)
+		animalGroup.POST("/:id/shares/:organizationId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsShare), animalHandler.Share)
+		animalGroup.DELETE("/:id/shares/:organizationId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsShare), animalHandler.Unshare)
+	}
+
+	animalImportService := service.NewAnimalImportService(animalRepo, accountService, locationService, animalTypeService, middleware.GetPermissionService(), service.NewAnimalImportParamsFromConfig())
+	animalImportHandler := handler.NewAnimalImportHandler(animalImportService)
+	{
+		animalGroup.POST("/import", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsCreate), animalImportHandler.Import)
+	}
 
-		animalGroup.POST("/:id/types/:typeId", middleware.BasicAuth, middleware.AdminOrChipperRequired, animalHandler.AddAnimalType)
-		animalGroup.PUT("/:id/types", middleware.BasicAuth, middleware.AdminOrChipperRequired, animalHandler.EditAnimalType)
-		animalGroup.DELETE("/:id/types/:typeId", middleware.BasicAuth, middleware.AdminOrChipperRequired, animalHandler.DeleteAnimalType)
+	tagRepo := repository.NewTagRepository(helpers.GetConnectionOrCreateAndGet())
+	tagService := service.NewTagService(tagRepo)
+	tagHandler := handler.NewTagHandler(tagService, animalService)
+	{
+		animalGroup.POST("/tags", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), tagHandler.UpdateAnimalsTags)
+		animalGroup.POST("/:id/tags/:tag", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), tagHandler.AddAnimalTag)
+		animalGroup.DELETE("/:id/tags/:tag", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), tagHandler.DeleteAnimalTag)
+	}
+	tagGroup := api.Group("tags")
+	{
+		tagGroup.GET("", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), tagHandler.Search)
 	}
 
 	animalLocationHandler := handler.NewAnimalLocationHandler(animalLocationService, animalService, locationService)
 	{
-		animalGroup.GET("/:id/locations", middleware.BasicAuth, animalLocationHandler.GetAnimalLocations)
-		animalGroup.POST("/:id/locations/:pointId", middleware.BasicAuth, middleware.AdminOrChipperRequired, animalLocationHandler.AddAnimalLocationPoint)
-		animalGroup.PUT("/:id/locations", middleware.BasicAuth, middleware.AdminOrChipperRequired, animalLocationHandler.EditAnimalLocationPoint)
-		animalGroup.DELETE("/:id/locations/:visitedPointId", middleware.BasicAuth, middleware.AdminRequired, animalLocationHandler.DeleteAnimalLocationPoint)
+		animalGroup.GET("/:id/locations", middleware.Auth, middleware.ScopeRequired(entity.LocationsReadScope), middleware.Require(permission.VisitedLocationsRead), animalLocationHandler.GetAnimalLocations)
+		animalGroup.POST("/:id/locations/:pointId", middleware.Auth, middleware.ScopeRequired(entity.LocationsWriteScope), middleware.Require(permission.VisitedLocationsCreate), animalLocationHandler.AddAnimalLocationPoint)
+		animalGroup.PUT("/:id/locations", middleware.Auth, middleware.ScopeRequired(entity.LocationsWriteScope), middleware.Require(permission.VisitedLocationsUpdate), animalLocationHandler.EditAnimalLocationPoint)
+		animalGroup.DELETE("/:id/locations/:visitedPointId", middleware.Auth, middleware.ScopeRequired(entity.LocationsWriteScope), middleware.Require(permission.VisitedLocationsDelete), animalLocationHandler.DeleteAnimalLocationPoint)
 	}
 
-	animalTypeHandler := handler.NewAnimalTypeHandler(animalTypeService, animalService)
+	animalMeasurementHandler := handler.NewAnimalMeasurementHandler(animalMeasurementService, animalService, animalLocationService, locationService)
+	{
+		animalGroup.GET("/:id/measurements", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), animalMeasurementHandler.GetMeasurements)
+		animalGroup.POST("/:id/measurements", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), animalMeasurementHandler.Create)
+	}
+
+	observationRepo := repository.NewObservationRepository(helpers.GetConnectionOrCreateAndGet())
+	observationService := service.NewObservationService(observationRepo)
+	observationHandler := handler.NewObservationHandler(observationService, animalService, middleware.GetPermissionService())
+	{
+		animalGroup.GET("/:id/observations", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), observationHandler.GetObservations)
+		animalGroup.POST("/:id/observations", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), observationHandler.Create)
+		animalGroup.GET("/:id/observations/:observationId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), observationHandler.Get)
+		animalGroup.PUT("/:id/observations/:observationId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), observationHandler.Update)
+		animalGroup.DELETE("/:id/observations/:observationId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), observationHandler.Delete)
+	}
+	observationGroup := api.Group("observations")
+	{
+		observationGroup.GET("/search", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), observationHandler.Search)
+	}
+
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, animalService)
+	{
+		animalGroup.GET("/:id/attachments", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), attachmentHandler.GetAttachments)
+		animalGroup.POST("/:id/attachments", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), attachmentHandler.Create)
+		animalGroup.GET("/:id/attachments/:attachmentId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), attachmentHandler.Download)
+		animalGroup.GET("/:id/attachments/:attachmentId/thumbnail", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), attachmentHandler.DownloadThumbnail)
+		animalGroup.DELETE("/:id/attachments/:attachmentId", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalsUpdate), attachmentHandler.Delete)
+	}
+
+	animalTypeHandler := handler.NewAnimalTypeHandler(animalTypeService, animalService, middleware.GetPermissionService())
 	animalTypeGroup := animalGroup.Group("types")
 	{
-		animalTypeGroup.GET("/:id", middleware.BasicAuth, animalTypeHandler.Get)
-		animalTypeGroup.POST("", middleware.BasicAuth, middleware.AdminOrChipperRequired, animalTypeHandler.Create)
-		animalTypeGroup.PUT("/:id", middleware.BasicAuth, middleware.AdminOrChipperRequired, animalTypeHandler.Update)
-		animalTypeGroup.DELETE("/:id", middleware.BasicAuth, middleware.AdminRequired, animalTypeHandler.Delete)
+		animalTypeGroup.GET("/:id", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalTypesRead), animalTypeHandler.Get)
+		animalTypeGroup.POST("", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalTypesCreate), animalTypeHandler.Create)
+		animalTypeGroup.PUT("/:id", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalTypesUpdate), animalTypeHandler.Update)
+		animalTypeGroup.DELETE("/:id", middleware.Auth, middleware.ScopeRequired(entity.AnimalsWriteScope), middleware.Require(permission.AnimalTypesDelete), animalTypeHandler.Delete)
 	}
 
-	accountHandler := handler.NewAccountHandler(accountService, animalService)
+	accountHandler := handler.NewAccountHandler(accountService, animalService, middleware.GetPermissionService())
 	accountGroup := api.Group("accounts")
 	{
-		accountGroup.GET("/:id", middleware.BasicAuth, accountHandler.Get)
-		accountGroup.GET("/search", middleware.BasicAuth, middleware.AdminRequired, accountHandler.Search)
-		accountGroup.PUT("/:id", middleware.BasicAuth, accountHandler.Update)
-		accountGroup.DELETE("/:id", middleware.BasicAuth, accountHandler.Delete)
-		accountGroup.POST("", middleware.BasicAuth, middleware.AdminRequired, accountHandler.Create)
+		accountGroup.GET("/:id", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), accountHandler.Get)
+		accountGroup.GET("/search", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), middleware.Require(permission.AccountsSearch), accountHandler.Search)
+		accountGroup.PUT("/:id", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), accountHandler.Update)
+		accountGroup.DELETE("/:id", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), accountHandler.Delete)
+		accountGroup.POST("", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.AccountsCreate), accountHandler.Create)
+		accountGroup.POST("/:id/activate", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.AccountsUpdateAny), accountHandler.Activate)
+		accountGroup.POST("/:id/reassign-animals", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.AccountsReassignAnimals), accountHandler.ReassignAnimals)
 	}
 
-	locationHandler := handler.NewLocationHandler(locationService, animalService)
+	locationHandler := handler.NewLocationHandler(locationService, animalService, middleware.GetPermissionService())
 	locationGroup := api.Group("locations")
 	{
-		locationGroup.GET("/:id", middleware.BasicAuth, locationHandler.Get)
-		locationGroup.GET("", middleware.BasicAuth, locationHandler.GetByCoordinates)
-		locationGroup.GET("/geohash", middleware.BasicAuth, locationHandler.GeoHashV1)
-		locationGroup.GET("/geohashv2", middleware.BasicAuth, locationHandler.GeoHashV2)
-		locationGroup.GET("/geohashv3", middleware.BasicAuth, locationHandler.GeoHashV3)
-		locationGroup.POST("", middleware.BasicAuth, middleware.AdminOrChipperRequired, locationHandler.Create)
-		locationGroup.PUT("/:id", middleware.BasicAuth, middleware.AdminOrChipperRequired, locationHandler.Update)
-		locationGroup.DELETE("/:id", middleware.BasicAuth, middleware.AdminRequired, locationHandler.Delete)
+		locationGroup.GET("/:id", middleware.Auth, middleware.ScopeRequired(entity.LocationsReadScope), middleware.Require(permission.LocationsRead), locationHandler.Get)
+		locationGroup.GET("", middleware.Auth, middleware.ScopeRequired(entity.LocationsReadScope), middleware.Require(permission.LocationsRead), locationHandler.GetByCoordinates)
+		locationGroup.GET("/geohash", middleware.Auth, middleware.ScopeRequired(entity.LocationsReadScope), middleware.Require(permission.LocationsRead), locationHandler.GeoHashV1)
+		locationGroup.GET("/geohashv2", middleware.Auth, middleware.ScopeRequired(entity.LocationsReadScope), middleware.Require(permission.LocationsRead), locationHandler.GeoHashV2)
+		locationGroup.GET("/geohashv3", middleware.Auth, middleware.ScopeRequired(entity.LocationsReadScope), middleware.Require(permission.LocationsRead), locationHandler.GeoHashV3)
+		locationGroup.POST("", middleware.Auth, middleware.ScopeRequired(entity.LocationsWriteScope), middleware.Require(permission.LocationsCreate), locationHandler.Create)
+		locationGroup.PUT("/:id", middleware.Auth, middleware.ScopeRequired(entity.LocationsWriteScope), middleware.Require(permission.LocationsUpdate), locationHandler.Update)
+		locationGroup.DELETE("/:id", middleware.Auth, middleware.ScopeRequired(entity.LocationsWriteScope), middleware.Require(permission.LocationsDelete), locationHandler.Delete)
 	}
 
 	authRepo := repository.NewAuthRepository(helpers.GetConnectionOrCreateAndGet())
-	authService := service.NewAuthService(authRepo)
-	authHandler := handler.NewAuthHandler(authService, accountService)
+	notifierService, err := notifier.NewNotifier(notifier.NewParamsFromConfig())
+	if err != nil {
+		log.Fatal(err)
+	}
+	authService := service.NewAuthService(authRepo, accountService, passwordService, tokenService, notifierService)
+	authHandler := handler.NewAuthHandler(authService, accountService, middleware.GetLoginThrottle(), middleware.GetPasswordResetThrottle(), middleware.GetAccountCache(), middleware.GetAuditService())
 	{
 		r.POST("api/registration", authHandler.Register)
 	}
+	authGroup := api.Group("auth")
+	{
+		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/refresh", authHandler.Refresh)
+		authGroup.POST("/logout", authHandler.Logout)
+		authGroup.POST("/password-reset", authHandler.RequestPasswordReset)
+		authGroup.POST("/password-reset/confirm", authHandler.ResetPassword)
+		authGroup.GET("/lockouts", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), middleware.Require(permission.LockoutsManage), authHandler.GetLockouts)
+		authGroup.GET("/cache-stats", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), middleware.Require(permission.MetricsRead), authHandler.GetCacheStats)
+		authGroup.DELETE("/lockouts", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.LockoutsManage), authHandler.ClearLockout)
+	}
+
+	{
+		accountGroup.PUT("/:id/password", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), authHandler.ChangePassword)
+		accountGroup.POST("/:id/password-reset", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.AccountsUpdateAny), authHandler.RequestAccountPasswordReset)
+	}
+
+	middleware.GetAuditService().StartCleanup()
+	securityEventHandler := handler.NewSecurityEventHandler(middleware.GetAuditService(), accountService, middleware.GetPermissionService())
+	{
+		accountGroup.GET("/:id/security-events", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), securityEventHandler.GetAccountEvents)
+	}
 
 	areaHandler := handler.NewAreaHandler(areaService, areaRepo)
 	areaGroup := api.Group("areas")
 	{
-		areaGroup.GET("/:id", middleware.BasicAuth, areaHandler.Get)
-		areaGroup.POST("", middleware.BasicAuth, middleware.AdminRequired, areaHandler.Create)
-		areaGroup.PUT("/:id", middleware.BasicAuth, middleware.AdminRequired, areaHandler.Update)
-		areaGroup.DELETE("/:id", middleware.BasicAuth, middleware.AdminRequired, areaHandler.Delete)
-		areaGroup.GET("/:id/analytics", middleware.BasicAuth, areaHandler.Analytics)
+		areaGroup.GET("/:id", middleware.Auth, middleware.ScopeRequired(entity.AreasReadScope), middleware.Require(permission.AreasRead), areaHandler.Get)
+		areaGroup.POST("", middleware.Auth, middleware.ScopeRequired(entity.AreasWriteScope), middleware.Require(permission.AreasCreate), areaHandler.Create)
+		areaGroup.PUT("/:id", middleware.Auth, middleware.ScopeRequired(entity.AreasWriteScope), middleware.Require(permission.AreasUpdate), areaHandler.Update)
+		areaGroup.DELETE("/:id", middleware.Auth, middleware.ScopeRequired(entity.AreasWriteScope), middleware.Require(permission.AreasDelete), areaHandler.Delete)
+		areaGroup.GET("/:id/analytics", middleware.Auth, middleware.ScopeRequired(entity.AreasReadScope), middleware.Require(permission.AreasRead), areaHandler.Analytics)
+	}
+
+	exportService := service.NewExportService(animalRepo, animalLocationRepo, areaRepo, locationRepo, service.NewExportParamsFromConfig())
+	exportHandler := handler.NewExportHandler(exportService, animalService, areaService, middleware.GetPermissionService())
+	{
+		animalGroup.GET("/export", middleware.Auth, middleware.ScopeRequired(entity.AnimalsReadScope), middleware.Require(permission.AnimalsRead), exportHandler.ExportAnimals)
+		animalGroup.GET("/:id/locations/export", middleware.Auth, middleware.ScopeRequired(entity.LocationsReadScope), middleware.Require(permission.VisitedLocationsRead), exportHandler.ExportAnimalLocations)
+		areaGroup.GET("/export", middleware.Auth, middleware.ScopeRequired(entity.AreasReadScope), middleware.Require(permission.AreasRead), exportHandler.ExportAreas)
+	}
+
+	organizationHandler := handler.NewOrganizationHandler(organizationService, accountService, middleware.GetPermissionService())
+	organizationGroup := api.Group("organizations")
+	{
+		organizationGroup.GET("/:id", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), organizationHandler.Get)
+		organizationGroup.GET("", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), organizationHandler.Search)
+		organizationGroup.POST("", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.OrganizationsManage), organizationHandler.Create)
+		organizationGroup.PUT("/:id", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.OrganizationsManage), organizationHandler.Update)
+		organizationGroup.GET("/:id/members", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), organizationHandler.GetMembers)
+		organizationGroup.PUT("/:id/members/:accountId", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.OrganizationMembersManage), organizationHandler.SetMember)
+		organizationGroup.DELETE("/:id/members/:accountId", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.OrganizationMembersManage), organizationHandler.RemoveMember)
+	}
+
+	apiKeyRepo := repository.NewApiKeyRepository(helpers.GetConnectionOrCreateAndGet())
+	apiKeyService := service.NewApiKeyService(apiKeyRepo)
+	apiKeyHandler := handler.NewApiKeyHandler(apiKeyService, accountService)
+	apiKeyGroup := api.Group("api-keys")
+	{
+		apiKeyGroup.GET("/:id", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), middleware.Require(permission.ApiKeysManage), apiKeyHandler.Get)
+		apiKeyGroup.GET("", middleware.Auth, middleware.ScopeRequired(entity.AccountsReadScope), middleware.Require(permission.ApiKeysManage), apiKeyHandler.Search)
+		apiKeyGroup.POST("", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.ApiKeysManage), apiKeyHandler.Create)
+		apiKeyGroup.POST("/:id/rotate", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.ApiKeysManage), apiKeyHandler.Rotate)
+		apiKeyGroup.DELETE("/:id", middleware.Auth, middleware.ScopeRequired(entity.AccountsWriteScope), middleware.Require(permission.ApiKeysManage), apiKeyHandler.Revoke)
 	}
 
 	return r
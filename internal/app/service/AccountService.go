@@ -1,6 +1,8 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"gorm.io/gorm"
@@ -9,7 +11,12 @@ import (
 	"it-planet-task/internal/app/model/entity"
 	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/accountcache"
+	"it-planet-task/internal/app/service/oidc"
+	"it-planet-task/internal/app/service/password"
 	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"log"
 	"net/http"
 )
 
@@ -17,19 +24,26 @@ type Account interface {
 	Get(id int) (*response.Account, *errorHandler.HttpErr)
 	GetByEmail(account *entity.Account) (*response.Account, error)
 	Update(account *entity.Account) (*response.Account, error)
-	Search(params *filter.AccountFilterParams) (*[]response.Account, error)
+	Search(params *filter.AccountFilterParams) (*[]response.Account, *paginator.Page, error)
 	IsAlreadyExists(account *entity.Account) bool
 	GetByCreds(account *entity.Account) *entity.Account
-	Delete(id int) error
+	Disable(id int) error
+	Activate(id int) error
+	ReassignAnimals(fromAccountId, toAccountId int, disable bool) (int64, error)
 	Create(account *entity.Account) (*response.Account, error)
+	MigratePasswords() (int, error)
+	SetPassword(id int, password string) error
+	GetByExternalIdentity(identity *oidc.Identity, policy oidc.AccountPolicy) (*entity.Account, *errorHandler.HttpErr)
 }
 
 type AccountService struct {
-	accountRepo repository.Account
+	accountRepo     repository.Account
+	passwordService password.Password
+	accountCache    accountcache.AccountCache
 }
 
-func NewAccountService(accountRepo repository.Account) Account {
-	return &AccountService{accountRepo: accountRepo}
+func NewAccountService(accountRepo repository.Account, passwordService password.Password, accountCache accountcache.AccountCache) Account {
+	return &AccountService{accountRepo: accountRepo, passwordService: passwordService, accountCache: accountCache}
 }
 
 func (a *AccountService) Get(id int) (*response.Account, *errorHandler.HttpErr) {
@@ -49,34 +63,71 @@ func (a *AccountService) Get(id int) (*response.Account, *errorHandler.HttpErr)
 	return accountResponse, nil
 }
 
-func (a *AccountService) Search(params *filter.AccountFilterParams) (*[]response.Account, error) {
+func (a *AccountService) Search(params *filter.AccountFilterParams) (*[]response.Account, *paginator.Page, error) {
 	var accountResponses *[]response.Account
 
-	accounts, err := a.accountRepo.Search(params)
+	accounts, page, err := a.accountRepo.Search(params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	accountResponses = mapper.AccountsToAccountResponses(accounts)
 
-	return accountResponses, nil
+	return accountResponses, page, nil
 }
 
 func (a *AccountService) IsAlreadyExists(account *entity.Account) bool {
 	return a.accountRepo.GetByEmail(account).Id != 0
 }
 
+// GetByCreds Получение аккаунта по email и паролю.
+// Если пароль хранится в открытом виде или хеширован устаревшими параметрами, то он перехешируется
 func (a *AccountService) GetByCreds(account *entity.Account) *entity.Account {
-	return a.accountRepo.GetByCreds(account)
+	acc := a.accountRepo.GetByEmail(account)
+	if acc.Id == 0 || !a.passwordService.Compare(acc.Password, account.Password) {
+		return &entity.Account{}
+	}
+
+	if a.passwordService.NeedsRehash(acc.Password) {
+		hashedPassword, err := a.passwordService.Hash(account.Password)
+		if err != nil {
+			log.Println("password rehash:", err)
+			return acc
+		}
+		err = a.accountRepo.UpdatePassword(acc.Id, hashedPassword)
+		if err != nil {
+			log.Println("password rehash:", err)
+			return acc
+		}
+		acc.Password = hashedPassword
+	}
+
+	return acc
 }
 
 func (a *AccountService) Update(account *entity.Account) (*response.Account, error) {
 	accountResponse := &response.Account{}
 
-	account, err := a.accountRepo.Update(account)
+	account.Status = entity.ActiveStatus
+	oldAccount, err := a.accountRepo.Get(account.Id)
+	if err == nil {
+		account.Status = oldAccount.Status
+		account.ExternalId = oldAccount.ExternalId
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	hashedPassword, err := a.passwordService.Hash(account.Password)
 	if err != nil {
 		return nil, err
 	}
+	account.Password = hashedPassword
+
+	account, err = a.accountRepo.Update(account)
+	if err != nil {
+		return nil, err
+	}
+	a.accountCache.Invalidate(account.Id)
 
 	accountResponse = mapper.AccountToAccountResponse(account)
 
@@ -90,14 +141,48 @@ func (a *AccountService) GetByEmail(account *entity.Account) (*response.Account,
 	return accountResponse, nil
 }
 
-func (a *AccountService) Delete(id int) error {
-	return a.accountRepo.Delete(id)
+// Disable Отключение аккаунта вместо удаления, чтобы сохранить историю чиппирования
+func (a *AccountService) Disable(id int) error {
+	err := a.accountRepo.UpdateStatus(id, entity.DisabledStatus)
+	if err != nil {
+		return err
+	}
+	a.accountCache.Invalidate(id)
+	return nil
+}
+
+func (a *AccountService) Activate(id int) error {
+	err := a.accountRepo.UpdateStatus(id, entity.ActiveStatus)
+	if err != nil {
+		return err
+	}
+	a.accountCache.Invalidate(id)
+	return nil
+}
+
+func (a *AccountService) ReassignAnimals(fromAccountId, toAccountId int, disable bool) (int64, error) {
+	reassigned, err := a.accountRepo.ReassignAnimals(fromAccountId, toAccountId, disable)
+	if err != nil {
+		return 0, err
+	}
+	if disable {
+		a.accountCache.Invalidate(fromAccountId)
+	}
+	return reassigned, nil
 }
 
 func (a *AccountService) Create(account *entity.Account) (*response.Account, error) {
 	accountResponse := &response.Account{}
 
-	account, err := a.accountRepo.Create(account)
+	account.Status = entity.ActiveStatus
+
+	hashedPassword, err := a.passwordService.Hash(account.Password)
+	if err != nil {
+		return nil, err
+	}
+	account.Password = hashedPassword
+
+	account, err = a.accountRepo.Create(account)
 	if err != nil {
 		return nil, err
 	}
@@ -106,3 +191,129 @@ func (a *AccountService) Create(account *entity.Account) (*response.Account, err
 
 	return accountResponse, nil
 }
+
+// MigratePasswords Хеширование всех паролей, хранящихся в открытом виде. Возвращает количество обновлённых аккаунтов
+func (a *AccountService) MigratePasswords() (int, error) {
+	accounts, err := a.accountRepo.GetAll()
+	if err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, account := range *accounts {
+		if a.passwordService.IsHashed(account.Password) {
+			continue
+		}
+
+		hashedPassword, err := a.passwordService.Hash(account.Password)
+		if err != nil {
+			return migrated, err
+		}
+		err = a.accountRepo.UpdatePassword(account.Id, hashedPassword)
+		if err != nil {
+			return migrated, err
+		}
+		a.accountCache.Invalidate(account.Id)
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// SetPassword Хеширование и сохранение нового пароля аккаунта
+func (a *AccountService) SetPassword(id int, password string) error {
+	hashedPassword, err := a.passwordService.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	err = a.accountRepo.UpdatePassword(id, hashedPassword)
+	if err != nil {
+		return err
+	}
+	a.accountCache.Invalidate(id)
+
+	return nil
+}
+
+// GetByExternalIdentity Аккаунт пользователя внешнего провайдера.
+// Аккаунт ищется по внешнему идентификатору, затем, если разрешено policy.LinkByEmail, по подтверждённому email,
+// и создаётся, если разрешено policy.AutoProvision.
+// Роль аккаунта, связанного с провайдером, синхронизируется только при policy.SyncRole.
+// Роль существующего аккаунта при связывании по email не меняется
+func (a *AccountService) GetByExternalIdentity(identity *oidc.Identity, policy oidc.AccountPolicy) (*entity.Account, *errorHandler.HttpErr) {
+	account, err := a.accountRepo.GetByExternalId(identity.ExternalId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	if account != nil {
+		if policy.SyncRole && identity.Role != "" && account.Role != identity.Role {
+			err = a.accountRepo.UpdateExternalIdentity(account.Id, identity.ExternalId, identity.Role)
+			if err != nil {
+				return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+			}
+			account.Role = identity.Role
+			a.accountCache.Invalidate(account.Id)
+		}
+		return account, nil
+	}
+
+	account = a.accountRepo.GetByEmail(&entity.Account{Email: identity.Email})
+	if account.Id == 0 {
+		if !policy.AutoProvision {
+			return nil, errorHandler.NewHttpErr("Account is not provisioned", http.StatusForbidden)
+		}
+		return a.provisionExternal(identity, policy.DefaultRole)
+	}
+
+	if !policy.LinkByEmail || account.ExternalId != nil || !identity.EmailVerified {
+		return nil, errorHandler.NewHttpErr("Account with this email is linked to another identity", http.StatusForbidden)
+	}
+
+	err = a.accountRepo.UpdateExternalIdentity(account.Id, identity.ExternalId, account.Role)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+	account.ExternalId = &identity.ExternalId
+	a.accountCache.Invalidate(account.Id)
+
+	return account, nil
+}
+
+// provisionExternal Создание аккаунта для пользователя внешнего провайдера.
+// Пароль случайный, войти по паролю можно только после его сброса
+func (a *AccountService) provisionExternal(identity *oidc.Identity, defaultRole string) (*entity.Account, *errorHandler.HttpErr) {
+	role := identity.Role
+	if role == "" {
+		role = defaultRole
+	}
+	if role == "" {
+		role = entity.UserRole
+	}
+
+	randomPassword := make([]byte, 32)
+	if _, err := rand.Read(randomPassword); err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+	hashedPassword, err := a.passwordService.Hash(base64.RawURLEncoding.EncodeToString(randomPassword))
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	account, err := a.accountRepo.Create(&entity.Account{
+		FirstName:  identity.FirstName,
+		LastName:   identity.LastName,
+		Email:      identity.Email,
+		Password:   hashedPassword,
+		Role:       role,
+		Status:     entity.ActiveStatus,
+		ExternalId: &identity.ExternalId,
+	})
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	log.Printf("Provisioned account %d for external identity %s", account.Id, identity.ExternalId)
+	return account, nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/chip"
+	"it-planet-task/internal/app/service/importer"
+	"it-planet-task/internal/app/service/permission"
+	"it-planet-task/internal/app/validator/AnimalValidator"
+	"it-planet-task/pkg/config"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultImportMaxSize = 10 << 20
+	DefaultImportMaxRows = 10000
+)
+
+// AnimalImportParams Ограничения файла импорта
+type AnimalImportParams struct {
+	// MaxSize наибольший размер файла в байтах
+	MaxSize int64
+	// MaxRows наибольшее число строк в файле
+	MaxRows int
+}
+
+// NewAnimalImportParamsFromConfig Чтение параметров из секции import конфигурационного файла
+func NewAnimalImportParamsFromConfig() AnimalImportParams {
+	return AnimalImportParams{
+		MaxSize: config.GetConfig().GetInt64("import.maxSize"),
+		MaxRows: config.GetConfig().GetInt("import.maxRows"),
+	}
+}
+
+type AnimalImport interface {
+	Import(r io.Reader, params *filter.AnimalImportParams) (*response.AnimalImport, *errorHandler.HttpErr)
+	MaxSize() int64
+}
+
+type AnimalImportService struct {
+	animalRepo        repository.Animal
+	accountService    Account
+	locationService   Location
+	animalTypeService AnimalType
+	permissionService permission.Permission
+	params            AnimalImportParams
+}
+
+func NewAnimalImportService(animalRepo repository.Animal, accountService Account, locationService Location, animalTypeService AnimalType, permissionService permission.Permission, params AnimalImportParams) AnimalImport {
+	if params.MaxSize <= 0 {
+		params.MaxSize = DefaultImportMaxSize
+	}
+	if params.MaxRows <= 0 {
+		params.MaxRows = DefaultImportMaxRows
+	}
+	return &AnimalImportService{animalRepo: animalRepo, accountService: accountService, locationService: locationService, animalTypeService: animalTypeService, permissionService: permissionService, params: params}
+}
+
+func (a *AnimalImportService) MaxSize() int64 {
+	return a.params.MaxSize
+}
+
+// Import Разбор файла, проверка строк по правилам POST /animals и создание животных в режиме params.Mode.
+// Ошибки строк не прерывают проверку, а попадают в отчёт
+func (a *AnimalImportService) Import(r io.Reader, params *filter.AnimalImportParams) (*response.AnimalImport, *errorHandler.HttpErr) {
+	rows, err := importer.Parse(params.Format, r, a.params.MaxRows)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.Is(err, importer.ErrTooManyRows):
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("file must contain at most %d rows", a.params.MaxRows), http.StatusRequestEntityTooLarge)
+		case errors.As(err, &maxBytesErr):
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("file must be at most %d bytes", a.params.MaxSize), http.StatusRequestEntityTooLarge)
+		}
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return a.importRows(rows, params), nil
+}
+
+// importRows Проверка и создание животных из разобранных строк
+func (a *AnimalImportService) importRows(rows []importer.Row, params *filter.AnimalImportParams) *response.AnimalImport {
+	report := &response.AnimalImport{
+		Format: params.Format,
+		Mode:   params.Mode,
+		DryRun: params.DryRun,
+		Total:  len(rows),
+		Rows:   make([]response.AnimalImportRow, len(rows)),
+	}
+
+	checker := a.newImportChecker(rows, params.Tenant)
+	animals := make([]*entity.Animal, 0, len(rows))
+	rowIndexes := make([]int, 0, len(rows))
+	for i, row := range rows {
+		report.Rows[i] = response.AnimalImportRow{Line: row.Line, Status: response.ImportRowValid}
+
+		if errs := checker.check(&row); len(errs) > 0 {
+			report.Rows[i].Status = response.ImportRowFailed
+			report.Rows[i].Errors = errs
+			report.Failed++
+			continue
+		}
+		animals = append(animals, newImportedAnimal(row.Animal, params))
+		rowIndexes = append(rowIndexes, i)
+	}
+
+	if params.DryRun || len(animals) == 0 {
+		return report
+	}
+	if params.Mode == filter.AtomicImport && report.Failed > 0 {
+		for _, i := range rowIndexes {
+			report.Rows[i].Status = response.ImportRowSkipped
+		}
+		return report
+	}
+
+	errs := a.animalRepo.CreateMany(animals, params.Mode == filter.AtomicImport)
+	for j, err := range errs {
+		row := &report.Rows[rowIndexes[j]]
+		switch {
+		case errors.Is(err, repository.ErrRolledBack):
+			row.Status = response.ImportRowSkipped
+		case err != nil:
+			row.Status = response.ImportRowFailed
+			row.Errors = []string{err.Error()}
+			report.Failed++
+		default:
+			row.Status = response.ImportRowCreated
+			row.AnimalId = &animals[j].Id
+			report.Created++
+		}
+	}
+
+	return report
+}
+
+// newImportedAnimal Животное из строки импорта с начальными замерами на момент чипирования
+func newImportedAnimal(animalInput *input.AnimalImport, params *filter.AnimalImportParams) *entity.Animal {
+	animal := mapper.AnimalInputToAnimal(&animalInput.Animal)
+	animal.LifeStatus = entity.Alive
+	animal.ChippingDateTime = time.Now()
+	if animalInput.ChippingDateTime != nil {
+		animal.ChippingDateTime = *animalInput.ChippingDateTime
+	}
+	if params.Tenant != nil {
+		animal.OrganizationId = params.Tenant.OrganizationId
+	}
+
+	values := map[string]float32{
+		entity.WeightMeasurement: animal.Weight,
+		entity.HeightMeasurement: animal.Height,
+		entity.LengthMeasurement: animal.Length,
+	}
+	for _, measurementType := range []string{entity.WeightMeasurement, entity.HeightMeasurement, entity.LengthMeasurement} {
+		animal.Measurements = append(animal.Measurements, entity.AnimalMeasurement{
+			Type:            measurementType,
+			Value:           values[measurementType],
+			Unit:            entity.MeasurementUnits[measurementType],
+			MeasuredAt:      animal.ChippingDateTime,
+			MeasuredById:    params.ImportedById,
+			LocationPointId: &animal.ChippingLocationId,
+		})
+	}
+
+	return animal
+}
+
+// importChecker Проверка строк импорта. Чипперы, точки и типы животных запрашиваются один раз на весь файл
+type importChecker struct {
+	service       *AnimalImportService
+	tenant        *filter.TenantScope
+	animalTypeIds map[int]bool
+	// chipperErrors, locationErrors результат проверки чиппера и точки, пустая строка - проверка пройдена
+	chipperErrors  map[int]string
+	locationErrors map[int]string
+	// chipCodeLines строка файла, в которой номер чипа встретился впервые
+	chipCodeLines map[string]int
+}
+
+func (a *AnimalImportService) newImportChecker(rows []importer.Row, tenant *filter.TenantScope) *importChecker {
+	checker := &importChecker{
+		service:        a,
+		tenant:         tenant,
+		animalTypeIds:  make(map[int]bool),
+		chipperErrors:  make(map[int]string),
+		locationErrors: make(map[int]string),
+		chipCodeLines:  make(map[string]int),
+	}
+
+	ids := make([]int, 0)
+	for _, row := range rows {
+		if row.Animal != nil {
+			ids = append(ids, row.Animal.AnimalTypeIds...)
+		}
+	}
+	if len(ids) == 0 {
+		return checker
+	}
+	animalTypes, err := a.animalTypeService.GetByIds(&ids)
+	if err == nil {
+		for _, animalType := range *animalTypes {
+			checker.animalTypeIds[animalType.Id] = true
+		}
+	}
+	return checker
+}
+
+func (i *importChecker) check(row *importer.Row) []string {
+	if row.Err != nil {
+		return []string{row.Err.Error()}
+	}
+
+	httpErr := AnimalValidator.ValidateAnimalImportInput(row.Animal)
+	if httpErr != nil {
+		return []string{httpErr.Err.Error()}
+	}
+
+	errs := make([]string, 0)
+	for _, animalTypeId := range row.Animal.AnimalTypeIds {
+		if !i.animalTypeIds[animalTypeId] {
+			errs = append(errs, fmt.Sprintf("Animal type with id %d does not exists", animalTypeId))
+		}
+	}
+	if message := i.checkChipper(*row.Animal.ChipperId); message != "" {
+		errs = append(errs, message)
+	}
+	if message := i.checkLocation(*row.Animal.ChippingLocationId); message != "" {
+		errs = append(errs, message)
+	}
+
+	if row.Animal.ChipCode != nil {
+		chipCode := chip.Normalize(*row.Animal.ChipCode)
+		if line, ok := i.chipCodeLines[chipCode]; ok {
+			errs = append(errs, fmt.Sprintf("Chip %s is already used on line %d", chipCode, line))
+		} else if i.service.animalRepo.IsChipRegistered(chipCode) {
+			errs = append(errs, fmt.Sprintf("Chip %s is already registered", chipCode))
+		} else {
+			i.chipCodeLines[chipCode] = row.Line
+		}
+	}
+
+	return errs
+}
+
+// checkChipper Чиппер должен существовать, быть активным и иметь право animals:chip
+func (i *importChecker) checkChipper(chipperId int) string {
+	if message, ok := i.chipperErrors[chipperId]; ok {
+		return message
+	}
+
+	message := ""
+	chipper, httpErr := i.service.accountService.Get(chipperId)
+	switch {
+	case httpErr != nil:
+		message = httpErr.Err.Error()
+	case chipper.Status == entity.DisabledStatus:
+		message = "Chipper account is disabled"
+	case !i.service.permissionService.HasPermission(chipper.Role, permission.AnimalsChip):
+		message = "Cant set chipper without permission " + permission.AnimalsChip
+	}
+
+	i.chipperErrors[chipperId] = message
+	return message
+}
+
+// checkLocation Точка чипирования должна существовать и принадлежать доступной организации
+func (i *importChecker) checkLocation(locationId int) string {
+	if message, ok := i.locationErrors[locationId]; ok {
+		return message
+	}
+
+	message := ""
+	location, httpErr := i.service.locationService.Get(locationId)
+	switch {
+	case httpErr != nil:
+		message = httpErr.Err.Error()
+	case !i.tenant.CanAccess(location.OrganizationId):
+		message = fmt.Sprintf("Location with id %d does not exists", locationId)
+	}
+
+	i.locationErrors[locationId] = message
+	return message
+}
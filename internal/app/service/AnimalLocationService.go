@@ -10,17 +10,19 @@ import (
 	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/repository"
 	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
 	"net/http"
 	"time"
 )
 
 type AnimalLocation interface {
 	Get(id int) (*response.AnimalLocation, *errorHandler.HttpErr)
-	GetAnimalLocations(animalId int, params *filter.AnimalLocationFilterParams) (*[]response.AnimalLocation, *errorHandler.HttpErr)
+	GetAnimalLocations(animalId int, params *filter.AnimalLocationFilterParams) (*[]response.AnimalLocation, *paginator.Page, *errorHandler.HttpErr)
 	AddAnimalLocationPoint(animalId int, pointId int) (*response.AnimalLocation, error)
 	EditAnimalLocationPoint(visitedLocationPointId int, locationPointId int) (*response.AnimalLocation, error)
 	DeleteAnimalLocationPoint(visitedPointId int) error
 	SearchForAreaAnalytics(params *filter.AreaAnalyticsFilterParams) (*[]entity.AnimalLocationForAreaAnalytics, *errorHandler.HttpErr)
+	GetCurrentLocationPointId(animal *response.Animal) (int, *errorHandler.HttpErr)
 }
 
 type AnimalLocationService struct {
@@ -31,21 +33,21 @@ func NewAnimalLocationService(animalLocationRepo repository.AnimalLocation) Anim
 	return &AnimalLocationService{animalLocationRepo: animalLocationRepo}
 }
 
-func (a *AnimalLocationService) GetAnimalLocations(animalId int, params *filter.AnimalLocationFilterParams) (*[]response.AnimalLocation, *errorHandler.HttpErr) {
+func (a *AnimalLocationService) GetAnimalLocations(animalId int, params *filter.AnimalLocationFilterParams) (*[]response.AnimalLocation, *paginator.Page, *errorHandler.HttpErr) {
 	var animalLocationsResponse *[]response.AnimalLocation
 
-	animalLocations, err := a.animalLocationRepo.GetAnimalLocations(animalId, params)
+	animalLocations, page, err := a.animalLocationRepo.GetAnimalLocations(animalId, params)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Animal with id %d does not exists", animalId), http.StatusNotFound)
+			return nil, nil, errorHandler.NewHttpErr(fmt.Sprintf("Animal with id %d does not exists", animalId), http.StatusNotFound)
 		} else {
-			return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+			return nil, nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
 		}
 	}
 
 	animalLocationsResponse = mapper.AnimalLocationsToAnimalLocationResponses(animalLocations)
 
-	return animalLocationsResponse, nil
+	return animalLocationsResponse, page, nil
 }
 
 func (a *AnimalLocationService) SearchForAreaAnalytics(params *filter.AreaAnalyticsFilterParams) (*[]entity.AnimalLocationForAreaAnalytics, *errorHandler.HttpErr) {
@@ -109,3 +111,17 @@ func (a *AnimalLocationService) Get(id int) (*response.AnimalLocation, *errorHan
 
 	return animalLocationResponse, nil
 }
+
+// GetCurrentLocationPointId Точка, в которой животное находится сейчас: последняя посещённая или точка чипирования
+func (a *AnimalLocationService) GetCurrentLocationPointId(animal *response.Animal) (int, *errorHandler.HttpErr) {
+	if len(animal.VisitedLocationsId) == 0 {
+		return animal.ChippingLocationId, nil
+	}
+
+	lastVisitedLocation, httpErr := a.Get(animal.VisitedLocationsId[len(animal.VisitedLocationsId)-1])
+	if httpErr != nil {
+		return 0, httpErr
+	}
+
+	return lastVisitedLocation.LocationPointId, nil
+}
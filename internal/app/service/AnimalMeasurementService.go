@@ -0,0 +1,96 @@
+package service
+
+import (
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/http"
+	"time"
+)
+
+type AnimalMeasurement interface {
+	Search(animalId int, params *filter.AnimalMeasurementFilterParams) (*[]response.AnimalMeasurement, *paginator.Page, *errorHandler.HttpErr)
+	Create(animalMeasurement *entity.AnimalMeasurement) (*response.AnimalMeasurement, *errorHandler.HttpErr)
+	RecordChanges(animal *response.Animal, previous *response.Animal, measuredById *int, locationPointId *int) *errorHandler.HttpErr
+}
+
+type AnimalMeasurementService struct {
+	animalMeasurementRepo repository.AnimalMeasurement
+}
+
+func NewAnimalMeasurementService(animalMeasurementRepo repository.AnimalMeasurement) AnimalMeasurement {
+	return &AnimalMeasurementService{animalMeasurementRepo: animalMeasurementRepo}
+}
+
+func (a *AnimalMeasurementService) Search(animalId int, params *filter.AnimalMeasurementFilterParams) (*[]response.AnimalMeasurement, *paginator.Page, *errorHandler.HttpErr) {
+	animalMeasurements, page, err := a.animalMeasurementRepo.Search(animalId, params)
+	if err != nil {
+		return nil, nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.AnimalMeasurementsToAnimalMeasurementResponses(animalMeasurements), page, nil
+}
+
+// Create Сохранение замера. Без указанного времени замер считается сделанным сейчас
+func (a *AnimalMeasurementService) Create(animalMeasurement *entity.AnimalMeasurement) (*response.AnimalMeasurement, *errorHandler.HttpErr) {
+	if animalMeasurement.MeasuredAt.IsZero() {
+		animalMeasurement.MeasuredAt = time.Now()
+	}
+
+	animalMeasurements, err := a.animalMeasurementRepo.Create(&[]entity.AnimalMeasurement{*animalMeasurement})
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.AnimalMeasurementToAnimalMeasurementResponse(&(*animalMeasurements)[0]), nil
+}
+
+// RecordChanges Запись замеров, значения которых изменились при создании или изменении животного.
+// Для нового животного previous равен nil, и замеры записываются на момент чипирования
+func (a *AnimalMeasurementService) RecordChanges(animal *response.Animal, previous *response.Animal, measuredById *int, locationPointId *int) *errorHandler.HttpErr {
+	measuredAt := time.Now()
+	if previous == nil {
+		measuredAt = animal.ChippingDateTime
+	}
+
+	values := map[string]float32{
+		entity.WeightMeasurement: animal.Weight,
+		entity.HeightMeasurement: animal.Height,
+		entity.LengthMeasurement: animal.Length,
+	}
+	var previousValues map[string]float32
+	if previous != nil {
+		previousValues = map[string]float32{
+			entity.WeightMeasurement: previous.Weight,
+			entity.HeightMeasurement: previous.Height,
+			entity.LengthMeasurement: previous.Length,
+		}
+	}
+
+	animalMeasurements := make([]entity.AnimalMeasurement, 0, len(values))
+	for _, measurementType := range []string{entity.WeightMeasurement, entity.HeightMeasurement, entity.LengthMeasurement} {
+		if previousValues != nil && previousValues[measurementType] == values[measurementType] {
+			continue
+		}
+		animalMeasurements = append(animalMeasurements, entity.AnimalMeasurement{
+			AnimalId:        animal.Id,
+			Type:            measurementType,
+			Value:           values[measurementType],
+			Unit:            entity.MeasurementUnits[measurementType],
+			MeasuredAt:      measuredAt,
+			MeasuredById:    measuredById,
+			LocationPointId: locationPointId,
+		})
+	}
+
+	_, err := a.animalMeasurementRepo.Create(&animalMeasurements)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -10,23 +10,39 @@ import (
 	"it-planet-task/internal/app/model/input"
 	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/chip"
 	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
 	"net/http"
 	"time"
 )
 
 type Animal interface {
 	Get(id int) (*response.Animal, *errorHandler.HttpErr)
-	Search(params *filter.AnimalFilterParams) (*[]response.Animal, error)
+	Search(params *filter.AnimalFilterParams) (*[]response.Animal, *paginator.Page, error)
 	GetAnimalsByAccountId(accountId int) (*[]entity.Animal, error)
 	GetAnimalsByAnimalTypeId(animalTypeId int) (*[]entity.Animal, error)
 	GetAnimalsByLocationId(locationId int) (*[]entity.Animal, error)
 	Create(animal *entity.Animal) (*response.Animal, error)
 	Update(newAnimal *entity.Animal, oldAnimal *response.Animal) (*response.Animal, error)
-	Delete(id int) error
+	Delete(id int) (*[]entity.Attachment, error)
 	AddAnimalType(animalId, typeId int) (*response.Animal, error)
 	EditAnimalType(animalId int, animalTypeUpdateInput *input.AnimalTypeUpdate) (*response.Animal, error)
 	DeleteAnimalType(animalId int, typeId int) (*response.Animal, error)
+	IsAccessible(animal *response.Animal, scope *filter.TenantScope) bool
+	CheckWritable(ids []int, scope *filter.TenantScope) *errorHandler.HttpErr
+	GetShares(animalId int) (*[]response.AnimalShare, error)
+	Share(animalId, organizationId int) (*response.AnimalShare, *errorHandler.HttpErr)
+	Unshare(animalId, organizationId int) *errorHandler.HttpErr
+	SetParents(animalId int, parents *input.AnimalParents) (*response.Animal, *errorHandler.HttpErr)
+	GetAncestors(animalId int, params *filter.LineageParams) (*response.AnimalLineage, *errorHandler.HttpErr)
+	GetDescendants(animalId int, params *filter.LineageParams) (*response.AnimalLineage, *errorHandler.HttpErr)
+	HasOffspring(animalId int) bool
+	HasOffspringBornAfter(animalId int, dateTime time.Time) bool
+	GetByChipCode(code string) (*response.Animal, *errorHandler.HttpErr)
+	GetChips(animalId int) (*[]response.AnimalChip, error)
+	Rechip(animal *response.Animal, code string, implantedById *int) (*response.Animal, *errorHandler.HttpErr)
+	IsChipRegistered(code string) bool
 }
 
 type AnimalService struct {
@@ -54,16 +70,16 @@ func (a *AnimalService) Get(id int) (*response.Animal, *errorHandler.HttpErr) {
 	return animalResponse, nil
 }
 
-func (a *AnimalService) Search(params *filter.AnimalFilterParams) (*[]response.Animal, error) {
+func (a *AnimalService) Search(params *filter.AnimalFilterParams) (*[]response.Animal, *paginator.Page, error) {
 	var animalResponses *[]response.Animal
 
-	animals, err := a.animalRepo.Search(params)
+	animals, page, err := a.animalRepo.Search(params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	animalResponses = mapper.AnimalsToAnimalResponses(animals)
 
-	return animalResponses, nil
+	return animalResponses, page, nil
 }
 
 func (a *AnimalService) GetAnimalsByAccountId(accountId int) (*[]entity.Animal, error) {
@@ -117,6 +133,11 @@ func (a *AnimalService) Update(newAnimal *entity.Animal, oldAnimal *response.Ani
 		newAnimal.DeathDateTime = oldAnimal.DeathDateTime
 	}
 	newAnimal.ChippingDateTime = oldAnimal.ChippingDateTime
+	newAnimal.OrganizationId = oldAnimal.OrganizationId
+	newAnimal.BirthDateTime = oldAnimal.BirthDateTime
+	newAnimal.MotherId = oldAnimal.MotherId
+	newAnimal.FatherId = oldAnimal.FatherId
+	newAnimal.ChipCode = oldAnimal.ChipCode
 
 	newAnimal, err := a.animalRepo.Update(newAnimal)
 	if err != nil {
@@ -128,7 +149,8 @@ func (a *AnimalService) Update(newAnimal *entity.Animal, oldAnimal *response.Ani
 	return animalResponse, nil
 }
 
-func (a *AnimalService) Delete(id int) error {
+// Delete Удаление животного. Возвращаются удалённые вместе с ним вложения, содержимое которых нужно удалить
+func (a *AnimalService) Delete(id int) (*[]entity.Attachment, error) {
 	return a.animalRepo.Delete(id)
 }
 
@@ -167,3 +189,226 @@ func (a *AnimalService) DeleteAnimalType(animalId int, typeId int) (*response.An
 
 	return animalResponse, nil
 }
+
+// IsAccessible Проверка, что животное принадлежит доступной организации или им поделились с активной организацией
+func (a *AnimalService) IsAccessible(animal *response.Animal, scope *filter.TenantScope) bool {
+	if scope.CanAccess(animal.OrganizationId) {
+		return true
+	}
+	return scope.OrganizationId != nil && a.animalRepo.IsSharedWith(animal.Id, *scope.OrganizationId)
+}
+
+// CheckWritable Проверка одним запросом, что все животные из ids существуют и доступны на запись в рамках scope.
+// Недоступные животные не раскрываются и считаются несуществующими, животные без организации и полученные
+// от других организаций доступны только на чтение
+func (a *AnimalService) CheckWritable(ids []int, scope *filter.TenantScope) *errorHandler.HttpErr {
+	animals, err := a.animalRepo.GetOrganizationsByIds(ids, scope)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	found := make(map[int]bool, len(*animals))
+	var readOnlyIds []int
+	for _, animal := range *animals {
+		found[animal.Id] = true
+		if !scope.CanWrite(animal.OrganizationId) {
+			readOnlyIds = append(readOnlyIds, animal.Id)
+		}
+	}
+
+	var missingIds []int
+	for _, id := range ids {
+		if !found[id] {
+			missingIds = append(missingIds, id)
+			found[id] = true
+		}
+	}
+	if len(missingIds) > 0 {
+		return errorHandler.NewHttpErr(fmt.Sprintf("Animals with ids %v do not exist", missingIds), http.StatusNotFound)
+	}
+	if len(readOnlyIds) > 0 {
+		return errorHandler.NewHttpErr(fmt.Sprintf("Animals with ids %v are read-only in organization", readOnlyIds), http.StatusForbidden)
+	}
+
+	return nil
+}
+
+func (a *AnimalService) GetShares(animalId int) (*[]response.AnimalShare, error) {
+	animalShares, err := a.animalRepo.GetShares(animalId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.AnimalSharesToAnimalShareResponses(animalShares), nil
+}
+
+func (a *AnimalService) Share(animalId, organizationId int) (*response.AnimalShare, *errorHandler.HttpErr) {
+	if a.animalRepo.IsSharedWith(animalId, organizationId) {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("Animal with id %d is already shared with organization %d", animalId, organizationId), http.StatusConflict)
+	}
+
+	animalShare, err := a.animalRepo.Share(&entity.AnimalShare{AnimalId: animalId, OrganizationId: organizationId})
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.AnimalShareToAnimalShareResponse(animalShare), nil
+}
+
+func (a *AnimalService) Unshare(animalId, organizationId int) *errorHandler.HttpErr {
+	if !a.animalRepo.IsSharedWith(animalId, organizationId) {
+		return errorHandler.NewHttpErr(fmt.Sprintf("Animal with id %d is not shared with organization %d", animalId, organizationId), http.StatusNotFound)
+	}
+
+	err := a.animalRepo.Unshare(animalId, organizationId)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// SetParents Изменение происхождения животного. Предок не может одновременно быть потомком животного
+func (a *AnimalService) SetParents(animalId int, parents *input.AnimalParents) (*response.Animal, *errorHandler.HttpErr) {
+	for _, parentId := range []*int{parents.MotherId, parents.FatherId} {
+		if parentId != nil && a.animalRepo.IsDescendant(animalId, *parentId) {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Animal with id %d is a descendant of animal %d", *parentId, animalId), http.StatusConflict)
+		}
+	}
+
+	animal, err := a.animalRepo.SetParents(animalId, parents)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.AnimalToAnimalResponse(animal), nil
+}
+
+// GetAncestors Дерево предков животного на params.Depth поколений
+func (a *AnimalService) GetAncestors(animalId int, params *filter.LineageParams) (*response.AnimalLineage, *errorHandler.HttpErr) {
+	animals, err := a.animalRepo.GetAncestors(animalId, params.Depth, params.Tenant)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	animalsById := make(map[int]*entity.Animal, len(*animals))
+	for i := range *animals {
+		animalsById[(*animals)[i].Id] = &(*animals)[i]
+	}
+	if animalsById[animalId] == nil {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("Animal with id %d does not exists", animalId), http.StatusNotFound)
+	}
+
+	return buildAncestors(animalsById[animalId], animalsById, params.Depth), nil
+}
+
+// GetDescendants Дерево потомков животного на params.Depth поколений
+func (a *AnimalService) GetDescendants(animalId int, params *filter.LineageParams) (*response.AnimalLineage, *errorHandler.HttpErr) {
+	animals, err := a.animalRepo.GetDescendants(animalId, params.Depth, params.Tenant)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	var root *entity.Animal
+	offspring := make(map[int][]*entity.Animal)
+	for i := range *animals {
+		animal := &(*animals)[i]
+		if animal.Id == animalId {
+			root = animal
+		}
+		for _, parentId := range []*int{animal.MotherId, animal.FatherId} {
+			if parentId != nil {
+				offspring[*parentId] = append(offspring[*parentId], animal)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("Animal with id %d does not exists", animalId), http.StatusNotFound)
+	}
+
+	return buildDescendants(root, offspring, params.Depth), nil
+}
+
+func (a *AnimalService) HasOffspring(animalId int) bool {
+	return a.animalRepo.HasOffspring(animalId)
+}
+
+// HasOffspringBornAfter Проверка, что у животного есть потомки, родившиеся после dateTime
+func (a *AnimalService) HasOffspringBornAfter(animalId int, dateTime time.Time) bool {
+	return a.animalRepo.HasOffspringBornAfter(animalId, dateTime)
+}
+
+// buildAncestors Построение дерева предков. Родители, которых нет в animalsById, в дерево не попадают
+func buildAncestors(animal *entity.Animal, animalsById map[int]*entity.Animal, depth int) *response.AnimalLineage {
+	node := mapper.AnimalToAnimalLineageResponse(animal)
+	if depth == 0 {
+		return node
+	}
+
+	if animal.MotherId != nil && animalsById[*animal.MotherId] != nil {
+		node.Mother = buildAncestors(animalsById[*animal.MotherId], animalsById, depth-1)
+	}
+	if animal.FatherId != nil && animalsById[*animal.FatherId] != nil {
+		node.Father = buildAncestors(animalsById[*animal.FatherId], animalsById, depth-1)
+	}
+	return node
+}
+
+// buildDescendants Построение дерева потомков, потомки одного животного упорядочены по id
+func buildDescendants(animal *entity.Animal, offspring map[int][]*entity.Animal, depth int) *response.AnimalLineage {
+	node := mapper.AnimalToAnimalLineageResponse(animal)
+	if depth == 0 {
+		return node
+	}
+
+	for _, child := range offspring[animal.Id] {
+		node.Offspring = append(node.Offspring, *buildDescendants(child, offspring, depth-1))
+	}
+	return node
+}
+
+// GetByChipCode Поиск животного по номеру чипа. Номер может быть записан с разделителями
+func (a *AnimalService) GetByChipCode(code string) (*response.Animal, *errorHandler.HttpErr) {
+	code = chip.Normalize(code)
+	animal, err := a.animalRepo.GetByChipCode(code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Animal with chip %s does not exists", code), http.StatusNotFound)
+		}
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.AnimalToAnimalResponse(animal), nil
+}
+
+func (a *AnimalService) GetChips(animalId int) (*[]response.AnimalChip, error) {
+	animalChips, err := a.animalRepo.GetChips(animalId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.AnimalChipsToAnimalChipResponses(animalChips), nil
+}
+
+func (a *AnimalService) IsChipRegistered(code string) bool {
+	return a.animalRepo.IsChipRegistered(chip.Normalize(code))
+}
+
+// Rechip Установка нового чипа животному. Номера чипов не используются повторно
+func (a *AnimalService) Rechip(animal *response.Animal, code string, implantedById *int) (*response.Animal, *errorHandler.HttpErr) {
+	code = chip.Normalize(code)
+	if a.animalRepo.IsChipRegistered(code) {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("Chip %s is already registered", code), http.StatusConflict)
+	}
+
+	updatedAnimal, err := a.animalRepo.Rechip(animal.Id, &entity.AnimalChip{
+		Code:          code,
+		ImplantedAt:   time.Now(),
+		ImplantedById: implantedById,
+	})
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.AnimalToAnimalResponse(updatedAnimal), nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/http"
+	"time"
+)
+
+const (
+	apiKeyPrefixLength = 8
+	apiKeySecretLength = 32
+
+	// lastUsedPrecision точность времени последнего использования ключа. Чаще время не обновляется,
+	// чтобы каждый запрос с ключом не приводил к записи в БД
+	lastUsedPrecision = time.Minute
+)
+
+type ApiKey interface {
+	Get(id int) (*response.ApiKey, *errorHandler.HttpErr)
+	Search(params *filter.ApiKeyFilterParams) (*[]response.ApiKey, *paginator.Page, error)
+	Create(apiKey *entity.ApiKey) (*response.ApiKeyWithSecret, error)
+	Rotate(id int) (*response.ApiKeyWithSecret, *errorHandler.HttpErr)
+	Revoke(id int) *errorHandler.HttpErr
+	Authenticate(key string) (*entity.ApiKey, error)
+}
+
+type ApiKeyService struct {
+	apiKeyRepo repository.ApiKey
+}
+
+func NewApiKeyService(apiKeyRepo repository.ApiKey) ApiKey {
+	return &ApiKeyService{apiKeyRepo: apiKeyRepo}
+}
+
+func (a *ApiKeyService) Get(id int) (*response.ApiKey, *errorHandler.HttpErr) {
+	apiKeyResponse := &response.ApiKey{}
+
+	apiKey, err := a.apiKeyRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Api key with id %d does not exists", id), http.StatusNotFound)
+		} else {
+			return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	apiKeyResponse = mapper.ApiKeyToApiKeyResponse(apiKey)
+
+	return apiKeyResponse, nil
+}
+
+func (a *ApiKeyService) Search(params *filter.ApiKeyFilterParams) (*[]response.ApiKey, *paginator.Page, error) {
+	apiKeys, page, err := a.apiKeyRepo.Search(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mapper.ApiKeysToApiKeyResponses(apiKeys), page, nil
+}
+
+func (a *ApiKeyService) Create(apiKey *entity.ApiKey) (*response.ApiKeyWithSecret, error) {
+	key, prefix, err := generateApiKey()
+	if err != nil {
+		return nil, err
+	}
+	apiKey.Prefix = prefix
+	apiKey.KeyHash = hashApiKey(key)
+
+	apiKey, err = a.apiKeyRepo.Create(apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.ApiKeyWithSecret{ApiKey: *mapper.ApiKeyToApiKeyResponse(apiKey), Key: key}, nil
+}
+
+// Rotate Выпуск нового секрета для ключа. Старый секрет перестаёт действовать
+func (a *ApiKeyService) Rotate(id int) (*response.ApiKeyWithSecret, *errorHandler.HttpErr) {
+	apiKeyResponse, httpErr := a.Get(id)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	if apiKeyResponse.RevokedAt != nil {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("Api key with id %d is revoked", id), http.StatusBadRequest)
+	}
+
+	key, prefix, err := generateApiKey()
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	apiKey, err := a.apiKeyRepo.UpdateKey(id, prefix, hashApiKey(key))
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return &response.ApiKeyWithSecret{ApiKey: *mapper.ApiKeyToApiKeyResponse(apiKey), Key: key}, nil
+}
+
+func (a *ApiKeyService) Revoke(id int) *errorHandler.HttpErr {
+	_, httpErr := a.Get(id)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	err := a.apiKeyRepo.Revoke(id)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Authenticate Поиск действующего ключа по секрету и обновление времени последнего использования, если оно старше минуты
+func (a *ApiKeyService) Authenticate(key string) (*entity.ApiKey, error) {
+	apiKey, err := a.apiKeyRepo.GetByHash(hashApiKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt != nil && now.Sub(*apiKey.LastUsedAt) < lastUsedPrecision {
+		return apiKey, nil
+	}
+	err = a.apiKeyRepo.UpdateLastUsed(apiKey.Id, now)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.LastUsedAt = &now
+
+	return apiKey, nil
+}
+
+// generateApiKey Генерация ключа вида ipk_<prefix>_<secret>. Префикс хранится открыто для отображения в списке ключей
+func generateApiKey() (string, string, error) {
+	b := make([]byte, apiKeySecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	secret := base64.RawURLEncoding.EncodeToString(b)
+	prefix := hex.EncodeToString(b[:apiKeyPrefixLength/2])
+
+	return fmt.Sprintf("ipk_%s_%s", prefix, secret), prefix, nil
+}
+
+func hashApiKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
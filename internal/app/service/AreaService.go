@@ -12,6 +12,8 @@ import (
 	"it-planet-task/internal/app/service/geometry"
 	"it-planet-task/internal/app/validator/AreaValidator"
 	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"math"
 	"net/http"
 	"net/url"
 )
@@ -21,18 +23,20 @@ type Area interface {
 	Create(area *entity.Area) (*response.Area, *errorHandler.HttpErr)
 	Update(area *entity.Area) (*response.Area, *errorHandler.HttpErr)
 	Delete(id int) error
-	Search(params *filter.AreaFilterParams) (*[]response.Area, *errorHandler.HttpErr)
+	Search(params *filter.AreaFilterParams) (*[]response.Area, *paginator.Page, *errorHandler.HttpErr)
 	Analytics(areaId int, params *filter.AreaAnalyticsFilterParams) (*response.AreaAnalytics, *errorHandler.HttpErr)
+	GetInsideLocationIds(area *response.Area) ([]int, *errorHandler.HttpErr)
 }
 
 type AreaService struct {
 	areaRepo              repository.Area
 	animalLocationService AnimalLocation
+	locationService       Location
 	geometryService       geometry.Geometry
 }
 
-func NewAreaService(areaRepo repository.Area, animalLocationService AnimalLocation, geometryService geometry.Geometry) Area {
-	return &AreaService{areaRepo: areaRepo, animalLocationService: animalLocationService, geometryService: geometryService}
+func NewAreaService(areaRepo repository.Area, animalLocationService AnimalLocation, locationService Location, geometryService geometry.Geometry) Area {
+	return &AreaService{areaRepo: areaRepo, animalLocationService: animalLocationService, locationService: locationService, geometryService: geometryService}
 }
 
 func (a *AreaService) Get(id int) (*response.Area, *errorHandler.HttpErr) {
@@ -59,7 +63,7 @@ func (a *AreaService) Create(area *entity.Area) (*response.Area, *errorHandler.H
 		query := fmt.Sprintf("size=%d&from=%d", size, from)
 		values, _ := url.ParseQuery(query)
 		params, _ := filter.NewAreaFilterParams(values)
-		existingAreas, err := a.areaRepo.Search(params)
+		existingAreas, _, err := a.areaRepo.Search(params)
 
 		if err != nil {
 			return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
@@ -95,7 +99,7 @@ func (a *AreaService) Update(area *entity.Area) (*response.Area, *errorHandler.H
 		query := fmt.Sprintf("size=%d&from=%d", size, from)
 		values, _ := url.ParseQuery(query)
 		params, _ := filter.NewAreaFilterParams(values)
-		existingAreas, err := a.areaRepo.Search(params)
+		existingAreas, _, err := a.areaRepo.Search(params)
 
 		if err != nil {
 			return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
@@ -132,17 +136,17 @@ func (a *AreaService) Delete(id int) error {
 	return a.areaRepo.Delete(id)
 }
 
-func (a *AreaService) Search(params *filter.AreaFilterParams) (*[]response.Area, *errorHandler.HttpErr) {
+func (a *AreaService) Search(params *filter.AreaFilterParams) (*[]response.Area, *paginator.Page, *errorHandler.HttpErr) {
 	areaResponses := &[]response.Area{}
 
-	areas, err := a.areaRepo.Search(params)
+	areas, page, err := a.areaRepo.Search(params)
 	if err != nil {
-		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+		return nil, nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
 	}
 
 	areaResponses = mapper.AreasToAreaResponses(areas)
 
-	return areaResponses, nil
+	return areaResponses, page, nil
 }
 
 func setTypeMap(mp map[int]map[int]bool, animalTypeId int, animalId int, value bool) {
@@ -252,3 +256,35 @@ func (a *AreaService) Analytics(areaId int, params *filter.AreaAnalyticsFilterPa
 	countTrueEntities(&areaAnalyticsResponse.TotalAnimalsGone, uniqueAreaExits)
 	return &areaAnalyticsResponse, nil
 }
+
+// GetInsideLocationIds Получение идентификаторов точек, лежащих внутри зоны или на её границе.
+// Кандидаты выбираются по описанному прямоугольнику зоны и проверяются по её многоугольнику
+func (a *AreaService) GetInsideLocationIds(areaResponse *response.Area) ([]int, *errorHandler.HttpErr) {
+	ids := make([]int, 0)
+	if len(areaResponse.AreaPoints) == 0 {
+		return ids, nil
+	}
+
+	minLatitude, maxLatitude := *areaResponse.AreaPoints[0].Latitude, *areaResponse.AreaPoints[0].Latitude
+	minLongitude, maxLongitude := *areaResponse.AreaPoints[0].Longitude, *areaResponse.AreaPoints[0].Longitude
+	for _, point := range areaResponse.AreaPoints[1:] {
+		minLatitude = math.Min(minLatitude, *point.Latitude)
+		maxLatitude = math.Max(maxLatitude, *point.Latitude)
+		minLongitude = math.Min(minLongitude, *point.Longitude)
+		maxLongitude = math.Max(maxLongitude, *point.Longitude)
+	}
+
+	locations, err := a.locationService.GetInBoundingBox(minLatitude, maxLatitude, minLongitude, maxLongitude)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	area := mapper.AreaResponseToArea(areaResponse)
+	for _, location := range *locations {
+		if a.geometryService.IsPointInsideArea(mapper.LocationToAreaPoint(&location), area, true) {
+			ids = append(ids, location.Id)
+		}
+	}
+
+	return ids, nil
+}
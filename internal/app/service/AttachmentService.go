@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/blobstore"
+	"it-planet-task/internal/app/service/thumbnail"
+	"it-planet-task/internal/app/validator/AttachmentValidator"
+	"it-planet-task/pkg/config"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"log"
+	"net/http"
+)
+
+const DefaultAttachmentMaxSize = 10 << 20
+
+// sniffLength количество первых байт файла, по которым определяется его тип
+const sniffLength = 512
+
+// AttachmentParams Ограничения на загружаемые файлы
+type AttachmentParams struct {
+	MaxSize       int64
+	ThumbnailSize int
+	// ThumbnailMaxPixels Изображения с большим количеством пикселей сохраняются без миниатюры
+	ThumbnailMaxPixels int
+}
+
+// NewAttachmentParamsFromConfig Чтение параметров из секции attachments конфигурационного файла
+func NewAttachmentParamsFromConfig() AttachmentParams {
+	return AttachmentParams{
+		MaxSize:            config.GetConfig().GetInt64("attachments.maxSize"),
+		ThumbnailSize:      config.GetConfig().GetInt("attachments.thumbnailSize"),
+		ThumbnailMaxPixels: config.GetConfig().GetInt("attachments.thumbnailMaxPixels"),
+	}
+}
+
+type Attachment interface {
+	Get(id int) (*response.Attachment, *errorHandler.HttpErr)
+	Search(animalId int, params *filter.AttachmentFilterParams) (*[]response.Attachment, *paginator.Page, *errorHandler.HttpErr)
+	Create(attachment *entity.Attachment, content io.ReadSeeker) (*response.Attachment, *errorHandler.HttpErr)
+	Open(id int, thumbnail bool) (io.ReadCloser, *errorHandler.HttpErr)
+	Delete(id int) *errorHandler.HttpErr
+	DeleteContent(attachments *[]entity.Attachment)
+	MaxSize() int64
+}
+
+type AttachmentService struct {
+	attachmentRepo repository.Attachment
+	blobStore      blobstore.BlobStore
+	params         AttachmentParams
+}
+
+func NewAttachmentService(attachmentRepo repository.Attachment, blobStore blobstore.BlobStore, params AttachmentParams) Attachment {
+	if params.MaxSize <= 0 {
+		params.MaxSize = DefaultAttachmentMaxSize
+	}
+	if params.ThumbnailSize <= 0 {
+		params.ThumbnailSize = thumbnail.DefaultSize
+	}
+	if params.ThumbnailMaxPixels <= 0 {
+		params.ThumbnailMaxPixels = thumbnail.DefaultMaxPixels
+	}
+	return &AttachmentService{attachmentRepo: attachmentRepo, blobStore: blobStore, params: params}
+}
+
+func (a *AttachmentService) Get(id int) (*response.Attachment, *errorHandler.HttpErr) {
+	attachment, httpErr := a.get(id)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	return mapper.AttachmentToAttachmentResponse(attachment), nil
+}
+
+func (a *AttachmentService) Search(animalId int, params *filter.AttachmentFilterParams) (*[]response.Attachment, *paginator.Page, *errorHandler.HttpErr) {
+	attachments, page, err := a.attachmentRepo.Search(animalId, params)
+	if err != nil {
+		return nil, nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.AttachmentsToAttachmentResponses(attachments), page, nil
+}
+
+// Create Сохранение файла. Тип файла определяется по содержимому, для изображений строится миниатюра
+func (a *AttachmentService) Create(attachment *entity.Attachment, content io.ReadSeeker) (*response.Attachment, *errorHandler.HttpErr) {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+	attachment.ContentType = http.DetectContentType(head[:n])
+
+	httpErr := AttachmentValidator.ValidateAttachment(attachment, a.params.MaxSize)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	key, err := newStorageKey(attachment.AnimalId)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+	if _, err = content.Seek(0, io.SeekStart); err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+	err = a.blobStore.Put(key, content)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+	attachment.StorageKey = key
+
+	if thumbnail.SupportedContentTypes[attachment.ContentType] {
+		attachment.ThumbnailKey = a.putThumbnail(key, content)
+	}
+
+	createdAttachment, err := a.attachmentRepo.Create(attachment)
+	if err != nil {
+		a.deleteBlobs(attachment)
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.AttachmentToAttachmentResponse(createdAttachment), nil
+}
+
+// Open Открытие содержимого вложения или его миниатюры. Закрыть результат должен вызывающий
+func (a *AttachmentService) Open(id int, thumbnail bool) (io.ReadCloser, *errorHandler.HttpErr) {
+	attachment, httpErr := a.get(id)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == nil {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Attachment with id %d has no thumbnail", id), http.StatusNotFound)
+		}
+		key = *attachment.ThumbnailKey
+	}
+
+	content, err := a.blobStore.Get(key)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Content of attachment with id %d is not found", id), http.StatusNotFound)
+		}
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	return content, nil
+}
+
+func (a *AttachmentService) Delete(id int) *errorHandler.HttpErr {
+	attachment, httpErr := a.get(id)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	err := a.attachmentRepo.Delete(id)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+	a.deleteBlobs(attachment)
+
+	return nil
+}
+
+// DeleteContent Удаление содержимого вложений, записи о которых уже удалены вместе с животным
+func (a *AttachmentService) DeleteContent(attachments *[]entity.Attachment) {
+	for _, attachment := range *attachments {
+		a.deleteBlobs(&attachment)
+	}
+}
+
+func (a *AttachmentService) MaxSize() int64 {
+	return a.params.MaxSize
+}
+
+func (a *AttachmentService) get(id int) (*entity.Attachment, *errorHandler.HttpErr) {
+	attachment, err := a.attachmentRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Attachment with id %d does not exists", id), http.StatusNotFound)
+		}
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return attachment, nil
+}
+
+// putThumbnail Сохранение миниатюры изображения. Изображение, которое не удалось разобрать,
+// сохраняется без миниатюры
+func (a *AttachmentService) putThumbnail(key string, content io.ReadSeeker) *string {
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		log.Println("thumbnail:", err)
+		return nil
+	}
+
+	data, err := thumbnail.Generate(content, a.params.ThumbnailSize, a.params.ThumbnailMaxPixels)
+	if err != nil {
+		log.Println("thumbnail:", err)
+		return nil
+	}
+
+	thumbnailKey := key + ".thumbnail.jpg"
+	err = a.blobStore.Put(thumbnailKey, bytes.NewReader(data))
+	if err != nil {
+		log.Println("thumbnail:", err)
+		return nil
+	}
+	return &thumbnailKey
+}
+
+// deleteBlobs Удаление содержимого вложения. Ошибки только логируются: запись о вложении уже удалена
+func (a *AttachmentService) deleteBlobs(attachment *entity.Attachment) {
+	keys := []string{attachment.StorageKey}
+	if attachment.ThumbnailKey != nil {
+		keys = append(keys, *attachment.ThumbnailKey)
+	}
+
+	for _, key := range keys {
+		if err := a.blobStore.Delete(key); err != nil {
+			log.Printf("delete blob %s: %s", key, err)
+		}
+	}
+}
+
+// newStorageKey Случайный ключ содержимого, чтобы имя файла пользователя не попадало в путь хранилища
+func newStorageKey(animalId int) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("animals/%d/%s", animalId, hex.EncodeToString(random)), nil
+}
@@ -1,28 +1,55 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
 	"it-planet-task/internal/app/mapper"
 	"it-planet-task/internal/app/model/entity"
 	"it-planet-task/internal/app/model/response"
 	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/notifier"
+	"it-planet-task/internal/app/service/password"
+	"it-planet-task/internal/app/service/token"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"time"
 )
 
 type Auth interface {
 	Register(newAccount *entity.Account) (*response.Account, error)
+	Login(account *entity.Account) (*response.Token, *errorHandler.HttpErr)
+	Refresh(refreshToken string) (*response.Token, *errorHandler.HttpErr)
+	Logout(refreshToken string) *errorHandler.HttpErr
+	ChangePassword(accountId int, oldPassword, newPassword string) *errorHandler.HttpErr
+	RequestPasswordReset(email string) *errorHandler.HttpErr
+	RequestAccountPasswordReset(accountId int) *errorHandler.HttpErr
+	ResetPassword(resetToken, newPassword string) *errorHandler.HttpErr
 }
 
 type AuthService struct {
-	authRepo repository.Auth
+	authRepo        repository.Auth
+	accountService  Account
+	passwordService password.Password
+	tokenService    token.Token
+	notifier        notifier.Notifier
 }
 
-func NewAuthService(authRepo repository.Auth) Auth {
-	return &AuthService{authRepo: authRepo}
+func NewAuthService(authRepo repository.Auth, accountService Account, passwordService password.Password, tokenService token.Token, notifier notifier.Notifier) Auth {
+	return &AuthService{authRepo: authRepo, accountService: accountService, passwordService: passwordService, tokenService: tokenService, notifier: notifier}
 }
 
 func (a *AuthService) Register(newAccount *entity.Account) (*response.Account, error) {
 	accountResponse := &response.Account{}
 
 	newAccount.Role = entity.UserRole
+	newAccount.Status = entity.ActiveStatus
+	hashedPassword, err := a.passwordService.Hash(newAccount.Password)
+	if err != nil {
+		return nil, err
+	}
+	newAccount.Password = hashedPassword
+
 	account, err := a.authRepo.Register(newAccount)
 	if err != nil {
 		return nil, err
@@ -32,3 +59,220 @@ func (a *AuthService) Register(newAccount *entity.Account) (*response.Account, e
 
 	return accountResponse, nil
 }
+
+// Login Проверка email и пароля и выдача пары access и refresh токенов
+func (a *AuthService) Login(account *entity.Account) (*response.Token, *errorHandler.HttpErr) {
+	authorizedAccount := a.accountService.GetByCreds(account)
+	if authorizedAccount.Id == 0 {
+		return nil, errorHandler.NewHttpErr("Invalid email or password", http.StatusUnauthorized)
+	}
+	if !authorizedAccount.IsActive() {
+		return nil, errorHandler.NewHttpErr("Account is disabled", http.StatusForbidden)
+	}
+
+	return a.issueTokens(authorizedAccount.Id)
+}
+
+// Refresh Обмен refresh токена на новую пару токенов. Использованный refresh токен отзывается,
+// новые токены выдаются только запросу, который отозвал его первым
+func (a *AuthService) Refresh(refreshToken string) (*response.Token, *errorHandler.HttpErr) {
+	storedToken, httpErr := a.getActiveRefreshToken(refreshToken)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	account, httpErr := a.accountService.Get(storedToken.AccountId)
+	if httpErr != nil {
+		return nil, errorHandler.NewHttpErr("Invalid refresh token", http.StatusUnauthorized)
+	}
+	if account.Status == entity.DisabledStatus {
+		return nil, errorHandler.NewHttpErr("Account is disabled", http.StatusForbidden)
+	}
+
+	revoked, err := a.authRepo.RevokeRefreshToken(storedToken.Id)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+	if !revoked {
+		return nil, errorHandler.NewHttpErr("Invalid refresh token", http.StatusUnauthorized)
+	}
+
+	return a.issueTokens(storedToken.AccountId)
+}
+
+// Logout Отзыв refresh токена. Выданные access токены действуют до истечения своего срока
+func (a *AuthService) Logout(refreshToken string) *errorHandler.HttpErr {
+	storedToken, httpErr := a.getActiveRefreshToken(refreshToken)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	_, err := a.authRepo.RevokeRefreshToken(storedToken.Id)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// ChangePassword Смена пароля владельцем аккаунта. Все выданные refresh токены отзываются
+func (a *AuthService) ChangePassword(accountId int, oldPassword, newPassword string) *errorHandler.HttpErr {
+	account, httpErr := a.accountService.Get(accountId)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	authorizedAccount := a.accountService.GetByCreds(&entity.Account{Email: account.Email, Password: oldPassword})
+	if authorizedAccount.Id != accountId {
+		return errorHandler.NewHttpErr("oldPassword is incorrect", http.StatusForbidden)
+	}
+
+	return a.setPassword(accountId, newPassword)
+}
+
+// RequestPasswordReset Отправка токена сброса пароля владельцу email.
+// Отсутствие аккаунта не является ошибкой, чтобы по ответу нельзя было узнать зарегистрированные email
+func (a *AuthService) RequestPasswordReset(email string) *errorHandler.HttpErr {
+	account, err := a.accountService.GetByEmail(&entity.Account{Email: email})
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+	if account.Id == 0 {
+		return nil
+	}
+
+	return a.sendPasswordResetToken(account)
+}
+
+// RequestAccountPasswordReset Отправка токена сброса пароля владельцу аккаунта по инициативе администратора
+func (a *AuthService) RequestAccountPasswordReset(accountId int) *errorHandler.HttpErr {
+	account, httpErr := a.accountService.Get(accountId)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	return a.sendPasswordResetToken(account)
+}
+
+// ResetPassword Установка нового пароля по одноразовому токену сброса
+func (a *AuthService) ResetPassword(resetToken, newPassword string) *errorHandler.HttpErr {
+	storedToken, err := a.authRepo.GetPasswordResetTokenByHash(a.tokenService.HashToken(resetToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorHandler.NewHttpErr("Invalid password reset token", http.StatusBadRequest)
+		} else {
+			return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if !storedToken.IsActive() {
+		return errorHandler.NewHttpErr("Invalid password reset token", http.StatusBadRequest)
+	}
+
+	used, err := a.authRepo.UsePasswordResetToken(storedToken.Id)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+	if !used {
+		return errorHandler.NewHttpErr("Invalid password reset token", http.StatusBadRequest)
+	}
+
+	httpErr := a.setPassword(storedToken.AccountId, newPassword)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	err = a.authRepo.UseAccountPasswordResetTokens(storedToken.AccountId)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (a *AuthService) setPassword(accountId int, newPassword string) *errorHandler.HttpErr {
+	err := a.accountService.SetPassword(accountId, newPassword)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	err = a.authRepo.RevokeAccountRefreshTokens(accountId)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (a *AuthService) sendPasswordResetToken(account *response.Account) *errorHandler.HttpErr {
+	resetToken, expiresAt, err := a.tokenService.NewPasswordResetToken()
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	_, err = a.authRepo.CreatePasswordResetToken(&entity.PasswordResetToken{
+		AccountId: account.Id,
+		TokenHash: a.tokenService.HashToken(resetToken),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	err = a.notifier.Send(&notifier.Message{
+		To:      account.Email,
+		Subject: "Password reset",
+		Body: fmt.Sprintf("Use this token to reset your password: %s\nThe token expires at %s",
+			resetToken, expiresAt.Format(time.RFC3339)),
+	})
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (a *AuthService) getActiveRefreshToken(refreshToken string) (*entity.RefreshToken, *errorHandler.HttpErr) {
+	storedToken, err := a.authRepo.GetRefreshTokenByHash(a.tokenService.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorHandler.NewHttpErr("Invalid refresh token", http.StatusUnauthorized)
+		} else {
+			return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	if !storedToken.IsActive() {
+		return nil, errorHandler.NewHttpErr("Invalid refresh token", http.StatusUnauthorized)
+	}
+
+	return storedToken, nil
+}
+
+func (a *AuthService) issueTokens(accountId int) (*response.Token, *errorHandler.HttpErr) {
+	accessToken, accessExpiresAt, err := a.tokenService.NewAccessToken(accountId)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	refreshToken, refreshExpiresAt, err := a.tokenService.NewRefreshToken()
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	_, err = a.authRepo.CreateRefreshToken(&entity.RefreshToken{
+		AccountId: accountId,
+		TokenHash: a.tokenService.HashToken(refreshToken),
+		ExpiresAt: refreshExpiresAt,
+	})
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusInternalServerError)
+	}
+
+	return &response.Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(accessExpiresAt).Round(time.Second).Seconds()),
+	}, nil
+}
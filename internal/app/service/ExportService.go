@@ -0,0 +1,186 @@
+package service
+
+import (
+	"io"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/exporter"
+	"it-planet-task/pkg/config"
+)
+
+// defaultExportBatchSize число записей, загружаемых из базы за один запрос, если оно не задано в конфигурации
+const defaultExportBatchSize = 500
+
+// animalExportColumns, animalLocationExportColumns, areaExportColumns Столбцы выгрузок, названные как поля ответов API
+var (
+	animalExportColumns = []string{
+		"id", "animalTypes", "weight", "height", "length", "gender", "lifeStatus",
+		"chippingDateTime", "chipperId", "chippingLocationId", "chippingLatitude", "chippingLongitude",
+		"visitedLocations", "deathDateTime", "birthDateTime", "motherId", "fatherId", "chipCode", "tags",
+	}
+	animalLocationExportColumns = []string{
+		"id", "animalId", "dateTimeOfVisitLocationPoint", "locationPointId", "latitude", "longitude",
+	}
+	areaExportColumns = []string{"id", "name"}
+)
+
+// ExportParams Параметры выгрузки
+type ExportParams struct {
+	// BatchSize число записей, загружаемых из базы за один запрос
+	BatchSize int
+}
+
+func NewExportParamsFromConfig() ExportParams {
+	params := ExportParams{BatchSize: config.GetConfig().GetInt("export.batchSize")}
+	if params.BatchSize <= 0 {
+		params.BatchSize = defaultExportBatchSize
+	}
+	return params
+}
+
+// Export Потоковая выгрузка в формате exporter.CSV, exporter.NDJSON или exporter.GeoJSON.
+// Записи читаются из базы страницами, поэтому выгрузка не ограничена объёмом памяти
+type Export interface {
+	ExportAnimals(w io.Writer, format string, params *filter.AnimalFilterParams, viewer *mapper.Viewer) error
+	ExportAnimalLocations(w io.Writer, format string, animalId int, params *filter.AnimalLocationFilterParams, viewer *mapper.Viewer) error
+	ExportAreas(w io.Writer, format string, params *filter.AreaFilterParams) error
+}
+
+type ExportService struct {
+	animalRepo         repository.Animal
+	animalLocationRepo repository.AnimalLocation
+	areaRepo           repository.Area
+	locationRepo       repository.Location
+	params             ExportParams
+}
+
+func NewExportService(animalRepo repository.Animal, animalLocationRepo repository.AnimalLocation, areaRepo repository.Area, locationRepo repository.Location, params ExportParams) Export {
+	return &ExportService{animalRepo: animalRepo, animalLocationRepo: animalLocationRepo, areaRepo: areaRepo, locationRepo: locationRepo, params: params}
+}
+
+// ExportAnimals Выгрузка животных по фильтру поиска. Геометрия - точка чипирования
+func (e *ExportService) ExportAnimals(w io.Writer, format string, params *filter.AnimalFilterParams, viewer *mapper.Viewer) error {
+	writer, err := exporter.NewWriter(format, w, animalExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = e.animalRepo.SearchInBatches(params, e.params.BatchSize, func(animals []entity.Animal) error {
+		locationIds := make([]int, 0, len(animals))
+		for _, animal := range animals {
+			locationIds = append(locationIds, animal.ChippingLocationId)
+		}
+		sensitive, err := e.getSensitiveLocations(locationIds, viewer)
+		if err != nil {
+			return err
+		}
+
+		for i := range animals {
+			animal := mapper.AnimalToAnimalResponse(&animals[i])
+			location := mapper.RedactLocationResponse(mapper.LocationToLocationResponse(&animals[i].ChippingLocation), viewer, sensitive[animal.ChippingLocationId])
+			err = writer.Write([]any{
+				animal.Id, animal.AnimalTypesId, animal.Weight, animal.Height, animal.Length, animal.Gender, animal.LifeStatus,
+				animal.ChippingDateTime, animal.ChipperId, animal.ChippingLocationId, location.Latitude, location.Longitude,
+				animal.VisitedLocationsId, animal.DeathDateTime, animal.BirthDateTime, animal.MotherId, animal.FatherId, animal.ChipCode, animal.Tags,
+			}, locationGeometry(location))
+			if err != nil {
+				return err
+			}
+		}
+		return writer.Flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// ExportAnimalLocations Выгрузка посещённых животным точек по фильтру GET /animals/:id/locations
+func (e *ExportService) ExportAnimalLocations(w io.Writer, format string, animalId int, params *filter.AnimalLocationFilterParams, viewer *mapper.Viewer) error {
+	writer, err := exporter.NewWriter(format, w, animalLocationExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = e.animalLocationRepo.GetAnimalLocationsInBatches(animalId, params, e.params.BatchSize, func(animalLocations []entity.AnimalLocation) error {
+		locationIds := make([]int, 0, len(animalLocations))
+		for _, animalLocation := range animalLocations {
+			locationIds = append(locationIds, animalLocation.LocationPointId)
+		}
+		sensitive, err := e.getSensitiveLocations(locationIds, viewer)
+		if err != nil {
+			return err
+		}
+
+		for i, animalLocation := range animalLocations {
+			location := mapper.RedactLocationResponse(mapper.LocationToLocationResponse(&animalLocations[i].LocationPoint), viewer, sensitive[animalLocation.LocationPointId])
+			err = writer.Write([]any{
+				animalLocation.Id, animalLocation.AnimalId, animalLocation.DateTimeOfVisitLocationPoint, animalLocation.LocationPointId,
+				location.Latitude, location.Longitude,
+			}, locationGeometry(location))
+			if err != nil {
+				return err
+			}
+		}
+		return writer.Flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// ExportAreas Выгрузка зон. Геометрия - многоугольник зоны
+func (e *ExportService) ExportAreas(w io.Writer, format string, params *filter.AreaFilterParams) error {
+	writer, err := exporter.NewWriter(format, w, areaExportColumns)
+	if err != nil {
+		return err
+	}
+
+	err = e.areaRepo.SearchInBatches(params, e.params.BatchSize, func(areas []entity.Area) error {
+		for _, area := range areas {
+			vertices := make([][2]float64, 0, len(area.AreaPoints))
+			for _, point := range area.AreaPoints {
+				vertices = append(vertices, [2]float64{*point.Latitude, *point.Longitude})
+			}
+			if err := writer.Write([]any{area.Id, area.Name}, exporter.Polygon(vertices)); err != nil {
+				return err
+			}
+		}
+		return writer.Flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// getSensitiveLocations Точки из locationIds, координаты которых огрубляются для viewer
+func (e *ExportService) getSensitiveLocations(locationIds []int, viewer *mapper.Viewer) (map[int]bool, error) {
+	sensitive := make(map[int]bool)
+	if viewer.ExactLocations {
+		return sensitive, nil
+	}
+
+	sensitiveIds, err := e.locationRepo.GetSensitiveIds(locationIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range sensitiveIds {
+		sensitive[id] = true
+	}
+	return sensitive, nil
+}
+
+func locationGeometry(location *response.Location) *exporter.Geometry {
+	if location.Latitude == nil || location.Longitude == nil {
+		return nil
+	}
+	return exporter.Point(*location.Latitude, *location.Longitude)
+}
@@ -24,6 +24,8 @@ type Location interface {
 	GeoHashV1(location *entity.Location) (*string, *errorHandler.HttpErr)
 	GeoHashV2(location *entity.Location) (*string, *errorHandler.HttpErr)
 	GeoHashV3(location *entity.Location) (*string, *errorHandler.HttpErr)
+	IsSensitive(id int) bool
+	GetInBoundingBox(minLatitude, maxLatitude, minLongitude, maxLongitude float64) (*[]entity.Location, error)
 }
 
 type LocationService struct {
@@ -132,3 +134,12 @@ func (l *LocationService) GeoHashV3(location *entity.Location) (*string, *errorH
 
 	return &geoHashV3, nil
 }
+
+// IsSensitive Проверка, что точка связана с животным охраняемого типа
+func (l *LocationService) IsSensitive(id int) bool {
+	return l.locationRepo.IsSensitive(id)
+}
+
+func (l *LocationService) GetInBoundingBox(minLatitude, maxLatitude, minLongitude, maxLongitude float64) (*[]entity.Location, error) {
+	return l.locationRepo.GetInBoundingBox(minLatitude, maxLatitude, minLongitude, maxLongitude)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/http"
+	"time"
+)
+
+type Observation interface {
+	Get(id int) (*response.Observation, *errorHandler.HttpErr)
+	Search(params *filter.ObservationFilterParams) (*[]response.Observation, *paginator.Page, *errorHandler.HttpErr)
+	Create(observation *entity.Observation) (*response.Observation, *errorHandler.HttpErr)
+	Update(observation *entity.Observation) (*response.Observation, *errorHandler.HttpErr)
+	Delete(id int) *errorHandler.HttpErr
+}
+
+type ObservationService struct {
+	observationRepo repository.Observation
+}
+
+func NewObservationService(observationRepo repository.Observation) Observation {
+	return &ObservationService{observationRepo: observationRepo}
+}
+
+func (o *ObservationService) Get(id int) (*response.Observation, *errorHandler.HttpErr) {
+	observation, err := o.observationRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Observation with id %d does not exists", id), http.StatusNotFound)
+		}
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.ObservationToObservationResponse(observation), nil
+}
+
+func (o *ObservationService) Search(params *filter.ObservationFilterParams) (*[]response.Observation, *paginator.Page, *errorHandler.HttpErr) {
+	observations, page, err := o.observationRepo.Search(params)
+	if err != nil {
+		return nil, nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.ObservationsToObservationResponses(observations), page, nil
+}
+
+// Create Сохранение наблюдения. Без указанного времени наблюдение считается сделанным сейчас
+func (o *ObservationService) Create(observation *entity.Observation) (*response.Observation, *errorHandler.HttpErr) {
+	if observation.ObservedAt.IsZero() {
+		observation.ObservedAt = time.Now()
+	}
+
+	observation, err := o.observationRepo.Create(observation)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.ObservationToObservationResponse(observation), nil
+}
+
+// Update Изменение текста, посещения и времени наблюдения. Без указанного времени оно не меняется
+func (o *ObservationService) Update(observation *entity.Observation) (*response.Observation, *errorHandler.HttpErr) {
+	if observation.ObservedAt.IsZero() {
+		oldObservation, httpErr := o.Get(observation.Id)
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		observation.ObservedAt = oldObservation.ObservedAt
+	}
+
+	observation, err := o.observationRepo.Update(observation)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.ObservationToObservationResponse(observation), nil
+}
+
+func (o *ObservationService) Delete(id int) *errorHandler.HttpErr {
+	err := o.observationRepo.Delete(id)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/pkg/errorHandler"
+	"it-planet-task/pkg/paginator"
+	"net/http"
+)
+
+type Organization interface {
+	Get(id int) (*response.Organization, *errorHandler.HttpErr)
+	Search(params *filter.OrganizationFilterParams) (*[]response.Organization, *paginator.Page, error)
+	Create(organization *entity.Organization) (*response.Organization, *errorHandler.HttpErr)
+	Update(organization *entity.Organization) (*response.Organization, *errorHandler.HttpErr)
+	GetMembers(organizationId int) (*[]response.Membership, *errorHandler.HttpErr)
+	SetMember(membership *entity.Membership) (*response.Membership, *errorHandler.HttpErr)
+	RemoveMember(organizationId, accountId int) *errorHandler.HttpErr
+}
+
+type OrganizationService struct {
+	organizationRepo repository.Organization
+}
+
+func NewOrganizationService(organizationRepo repository.Organization) Organization {
+	return &OrganizationService{organizationRepo: organizationRepo}
+}
+
+func (o *OrganizationService) Get(id int) (*response.Organization, *errorHandler.HttpErr) {
+	organization, err := o.organizationRepo.Get(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorHandler.NewHttpErr(fmt.Sprintf("Organization with id %d does not exists", id), http.StatusNotFound)
+		} else {
+			return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	return mapper.OrganizationToOrganizationResponse(organization), nil
+}
+
+func (o *OrganizationService) Search(params *filter.OrganizationFilterParams) (*[]response.Organization, *paginator.Page, error) {
+	organizations, page, err := o.organizationRepo.Search(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mapper.OrganizationsToOrganizationResponses(organizations), page, nil
+}
+
+func (o *OrganizationService) Create(organization *entity.Organization) (*response.Organization, *errorHandler.HttpErr) {
+	if o.organizationRepo.GetByName(organization.Name).Id != 0 {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("Organization with name %s already exists", organization.Name), http.StatusConflict)
+	}
+
+	organization, err := o.organizationRepo.Create(organization)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.OrganizationToOrganizationResponse(organization), nil
+}
+
+func (o *OrganizationService) Update(organization *entity.Organization) (*response.Organization, *errorHandler.HttpErr) {
+	_, httpErr := o.Get(organization.Id)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	duplicateOrganization := o.organizationRepo.GetByName(organization.Name)
+	if duplicateOrganization.Id != 0 && duplicateOrganization.Id != organization.Id {
+		return nil, errorHandler.NewHttpErr(fmt.Sprintf("Organization with name %s already exists", organization.Name), http.StatusConflict)
+	}
+
+	organization, err := o.organizationRepo.Update(organization)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.OrganizationToOrganizationResponse(organization), nil
+}
+
+func (o *OrganizationService) GetMembers(organizationId int) (*[]response.Membership, *errorHandler.HttpErr) {
+	_, httpErr := o.Get(organizationId)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	memberships, err := o.organizationRepo.GetMembers(organizationId)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.MembershipsToMembershipResponses(memberships), nil
+}
+
+// SetMember Добавление аккаунта в организацию или изменение его роли в организации
+func (o *OrganizationService) SetMember(membership *entity.Membership) (*response.Membership, *errorHandler.HttpErr) {
+	_, httpErr := o.Get(membership.OrganizationId)
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	membership, err := o.organizationRepo.SaveMembership(membership)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return mapper.MembershipToMembershipResponse(membership), nil
+}
+
+func (o *OrganizationService) RemoveMember(organizationId, accountId int) *errorHandler.HttpErr {
+	_, err := o.organizationRepo.GetMembership(organizationId, accountId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errorHandler.NewHttpErr(fmt.Sprintf("Account with id %d is not a member of organization %d", accountId, organizationId), http.StatusNotFound)
+		} else {
+			return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	err = o.organizationRepo.DeleteMembership(organizationId, accountId)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return nil
+}
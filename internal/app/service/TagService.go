@@ -0,0 +1,42 @@
+package service
+
+import (
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+)
+
+type Tag interface {
+	Search(params *filter.TagFilterParams) (*[]response.Tag, *errorHandler.HttpErr)
+	UpdateAnimalTags(input *input.AnimalTags) (*response.AnimalTags, *errorHandler.HttpErr)
+}
+
+type TagService struct {
+	tagRepo repository.Tag
+}
+
+func NewTagService(tagRepo repository.Tag) Tag {
+	return &TagService{tagRepo: tagRepo}
+}
+
+func (t *TagService) Search(params *filter.TagFilterParams) (*[]response.Tag, *errorHandler.HttpErr) {
+	tags, err := t.tagRepo.Search(params)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return tags, nil
+}
+
+// UpdateAnimalTags Изменение меток животных. Имена меток в input должны быть нормализованы
+func (t *TagService) UpdateAnimalTags(input *input.AnimalTags) (*response.AnimalTags, *errorHandler.HttpErr) {
+	err := t.tagRepo.UpdateAnimalTags(input.AnimalIds, input.Add, input.Remove)
+	if err != nil {
+		return nil, errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+
+	return &response.AnimalTags{AnimalIds: input.AnimalIds, Add: input.Add, Remove: input.Remove}, nil
+}
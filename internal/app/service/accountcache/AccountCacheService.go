@@ -0,0 +1,155 @@
+package accountcache
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/cache"
+	"it-planet-task/pkg/config"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTTL     = 30 * time.Second
+	DefaultMaxSize = 10000
+)
+
+type AccountCache interface {
+	Generation() Generation
+	GetByCreds(email, password string) (*entity.Account, bool)
+	SetByCreds(email, password string, account *entity.Account, generation Generation)
+	GetById(id int) (*entity.Account, bool)
+	SetById(account *entity.Account, generation Generation)
+	Invalidate(accountId int)
+	Stats() cache.Stats
+}
+
+// Generation Номер последней инвалидации на момент чтения аккаунта из БД.
+// Аккаунт, прочитанный до инвалидации, не попадает в кеш, даже если сохраняется после неё
+type Generation uint64
+
+// Params Параметры кеша аутентифицированных аккаунтов
+type Params struct {
+	TTL     time.Duration
+	MaxSize int
+}
+
+// NewParamsFromConfig Чтение параметров из секции security.accountCache конфигурационного файла
+func NewParamsFromConfig() Params {
+	return Params{
+		TTL:     config.GetConfig().GetDuration("security.accountCache.ttl"),
+		MaxSize: config.GetConfig().GetInt("security.accountCache.maxSize"),
+	}
+}
+
+type AccountCacheService struct {
+	byCreds *cache.Cache[string, entity.Account]
+	byId    *cache.Cache[int, entity.Account]
+	// credsKey ключ HMAC для ключей кеша, чтобы пароли не хранились в памяти даже в виде простого хеша
+	credsKey []byte
+
+	mu          sync.Mutex
+	generation  Generation
+	invalidated map[int]Generation
+}
+
+func NewAccountCacheService(params Params) AccountCache {
+	if params.TTL <= 0 {
+		params.TTL = DefaultTTL
+	}
+	if params.MaxSize <= 0 {
+		params.MaxSize = DefaultMaxSize
+	}
+
+	credsKey := make([]byte, 32)
+	if _, err := rand.Read(credsKey); err != nil {
+		log.Fatal(err)
+	}
+
+	return &AccountCacheService{
+		byCreds:     cache.New[string, entity.Account](params.TTL, params.MaxSize),
+		byId:        cache.New[int, entity.Account](params.TTL, params.MaxSize),
+		credsKey:    credsKey,
+		invalidated: make(map[int]Generation),
+	}
+}
+
+// Generation Текущий номер инвалидации. Запрашивается до чтения аккаунта из БД и передаётся в SetByCreds и SetById
+func (a *AccountCacheService) Generation() Generation {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.generation
+}
+
+// GetByCreds Получение аккаунта, ранее успешно прошедшего проверку с такими email и паролем
+func (a *AccountCacheService) GetByCreds(email, password string) (*entity.Account, bool) {
+	account, ok := a.byCreds.Get(a.credsCacheKey(email, password))
+	if !ok {
+		return nil, false
+	}
+	return &account, true
+}
+
+func (a *AccountCacheService) SetByCreds(email, password string, account *entity.Account, generation Generation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.invalidated[account.Id] > generation {
+		return
+	}
+	a.byCreds.Set(a.credsCacheKey(email, password), *account)
+}
+
+func (a *AccountCacheService) GetById(id int) (*entity.Account, bool) {
+	account, ok := a.byId.Get(id)
+	if !ok {
+		return nil, false
+	}
+	return &account, true
+}
+
+func (a *AccountCacheService) SetById(account *entity.Account, generation Generation) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.invalidated[account.Id] > generation {
+		return
+	}
+	a.byId.Set(account.Id, *account)
+}
+
+// Invalidate Удаление всех записей аккаунта. Вызывается при изменении и удалении аккаунта.
+// Номер инвалидации аккаунта увеличивается, чтобы параллельно прочитанные устаревшие данные не вернулись в кеш
+func (a *AccountCacheService) Invalidate(accountId int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.generation++
+	a.invalidated[accountId] = a.generation
+
+	a.byId.Delete(accountId)
+	a.byCreds.DeleteFunc(func(_ string, account entity.Account) bool {
+		return account.Id == accountId
+	})
+}
+
+// Stats Суммарная статистика обоих кешей
+func (a *AccountCacheService) Stats() cache.Stats {
+	byCreds := a.byCreds.Stats()
+	byId := a.byId.Stats()
+	return cache.Stats{
+		Hits:      byCreds.Hits + byId.Hits,
+		Misses:    byCreds.Misses + byId.Misses,
+		Evictions: byCreds.Evictions + byId.Evictions,
+		Size:      byCreds.Size + byId.Size,
+	}
+}
+
+func (a *AccountCacheService) credsCacheKey(email, password string) string {
+	mac := hmac.New(sha256.New, a.credsKey)
+	mac.Write([]byte(email))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+	return hex.EncodeToString(mac.Sum(nil))
+}
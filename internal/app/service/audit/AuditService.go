@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/pkg/cache"
+	"it-planet-task/pkg/config"
+	"it-planet-task/pkg/paginator"
+	"log"
+	"time"
+)
+
+const (
+	DefaultRetention       = 90 * 24 * time.Hour
+	DefaultCleanupInterval = time.Hour
+	DefaultFailureInterval = time.Minute
+
+	successCacheMaxSize = 100000
+	failureCacheMaxSize = 100000
+)
+
+type Audit interface {
+	Record(securityEvent *entity.SecurityEvent)
+	Search(params *filter.SecurityEventFilterParams) (*[]response.SecurityEvent, *paginator.Page, error)
+	Purge() (int64, error)
+	StartCleanup()
+}
+
+// Params Параметры журнала аутентификаций.
+// Записи старше Retention удаляются раз в CleanupInterval.
+// Повторные успешные аутентификации аккаунта тем же способом с того же IP записываются не чаще раза в SuccessInterval,
+// иначе basic auth записывал бы каждый запрос.
+// Одинаковые неудачные попытки для email с того же IP записываются не чаще раза в FailureInterval,
+// чтобы перебор паролей не превращался в неограниченную запись в БД
+type Params struct {
+	Retention       time.Duration
+	CleanupInterval time.Duration
+	SuccessInterval time.Duration
+	FailureInterval time.Duration
+}
+
+// NewParamsFromConfig Чтение параметров из секции security.audit конфигурационного файла
+func NewParamsFromConfig() Params {
+	return Params{
+		Retention:       config.GetConfig().GetDuration("security.audit.retention"),
+		CleanupInterval: config.GetConfig().GetDuration("security.audit.cleanupInterval"),
+		SuccessInterval: config.GetConfig().GetDuration("security.audit.successInterval"),
+		FailureInterval: config.GetConfig().GetDuration("security.audit.failureInterval"),
+	}
+}
+
+type AuditService struct {
+	securityEventRepo repository.SecurityEvent
+	params            Params
+	recentSuccesses   *cache.Cache[string, time.Time]
+	recentFailures    *cache.Cache[string, time.Time]
+}
+
+func NewAuditService(securityEventRepo repository.SecurityEvent, params Params) Audit {
+	if params.Retention <= 0 {
+		params.Retention = DefaultRetention
+	}
+	if params.CleanupInterval <= 0 {
+		params.CleanupInterval = DefaultCleanupInterval
+	}
+	if params.FailureInterval <= 0 {
+		params.FailureInterval = DefaultFailureInterval
+	}
+
+	auditService := &AuditService{securityEventRepo: securityEventRepo, params: params}
+	if params.SuccessInterval > 0 {
+		auditService.recentSuccesses = cache.New[string, time.Time](params.SuccessInterval, successCacheMaxSize)
+	}
+	auditService.recentFailures = cache.New[string, time.Time](params.FailureInterval, failureCacheMaxSize)
+
+	return auditService
+}
+
+// Record Запись события. Ошибка записи не должна прерывать аутентификацию, поэтому только логируется
+func (a *AuditService) Record(securityEvent *entity.SecurityEvent) {
+	if securityEvent.Type == entity.AuthSuccessEvent && a.recentSuccesses != nil && securityEvent.AccountId != nil {
+		key := fmt.Sprintf("%d|%s|%s|%s", *securityEvent.AccountId, securityEvent.Method, securityEvent.Ip, securityEvent.UserAgent)
+		if _, ok := a.recentSuccesses.Get(key); ok {
+			return
+		}
+		a.recentSuccesses.Set(key, time.Now())
+	}
+	if securityEvent.Type == entity.AuthFailureEvent {
+		key := fmt.Sprintf("%s|%s|%s|%s", securityEvent.Email, securityEvent.Method, securityEvent.Ip, securityEvent.Reason)
+		if _, ok := a.recentFailures.Get(key); ok {
+			return
+		}
+		a.recentFailures.Set(key, time.Now())
+	}
+
+	err := a.securityEventRepo.Create(securityEvent)
+	if err != nil {
+		log.Println("security event:", err)
+	}
+}
+
+func (a *AuditService) Search(params *filter.SecurityEventFilterParams) (*[]response.SecurityEvent, *paginator.Page, error) {
+	securityEvents, page, err := a.securityEventRepo.Search(params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mapper.SecurityEventsToSecurityEventResponses(securityEvents), page, nil
+}
+
+// Purge Удаление записей старше срока хранения
+func (a *AuditService) Purge() (int64, error) {
+	return a.securityEventRepo.DeleteBefore(time.Now().Add(-a.params.Retention))
+}
+
+// StartCleanup Запуск периодического удаления устаревших записей в фоне
+func (a *AuditService) StartCleanup() {
+	go func() {
+		ticker := time.NewTicker(a.params.CleanupInterval)
+		defer ticker.Stop()
+
+		for ; ; <-ticker.C {
+			deleted, err := a.Purge()
+			if err != nil {
+				log.Println("security events cleanup:", err)
+				continue
+			}
+			if deleted > 0 {
+				log.Printf("Deleted %d expired security events", deleted)
+			}
+		}
+	}()
+}
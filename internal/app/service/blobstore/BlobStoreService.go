@@ -0,0 +1,131 @@
+package blobstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"it-planet-task/pkg/config"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	Local = "local"
+)
+
+const DefaultLocalPath = "storage/attachments"
+
+var (
+	ErrNotFound   = errors.New("blob is not found")
+	ErrInvalidKey = errors.New("blob key is invalid")
+)
+
+// BlobStore Хранилище содержимого файлов. Ключ - относительный путь вида "animals/1/ab12cd"
+type BlobStore interface {
+	Put(key string, r io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// Params Параметры хранилища файлов
+type Params struct {
+	Type      string
+	LocalPath string
+}
+
+// NewParamsFromConfig Чтение параметров из секции attachments.store конфигурационного файла
+func NewParamsFromConfig() Params {
+	return Params{
+		Type:      config.GetConfig().GetString("attachments.store.type"),
+		LocalPath: config.GetConfig().GetString("attachments.store.local.path"),
+	}
+}
+
+// NewBlobStore Создание хранилища указанного типа. По умолчанию файлы хранятся в локальной файловой системе
+func NewBlobStore(params Params) (BlobStore, error) {
+	switch params.Type {
+	case "", Local:
+		if params.LocalPath == "" {
+			params.LocalPath = DefaultLocalPath
+		}
+		return NewLocalBlobStore(params.LocalPath), nil
+	default:
+		return nil, fmt.Errorf("unknown blob store type %s", params.Type)
+	}
+}
+
+// LocalBlobStore Хранение файлов в каталоге root
+type LocalBlobStore struct {
+	root string
+}
+
+func NewLocalBlobStore(root string) *LocalBlobStore {
+	return &LocalBlobStore{root: root}
+}
+
+// Put Запись через временный файл, чтобы при ошибке не оставалось недописанного содержимого
+func (l *LocalBlobStore) Put(key string, r io.Reader) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = io.Copy(file, r)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (l *LocalBlobStore) Get(key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Delete Удаление файла. Отсутствующий файл не считается ошибкой
+func (l *LocalBlobStore) Delete(key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path Путь к файлу внутри root. Ключи, выходящие за пределы root, отклоняются
+func (l *LocalBlobStore) path(key string) (string, error) {
+	cleanKey := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleanKey) || cleanKey == "." || cleanKey == ".." ||
+		strings.HasPrefix(cleanKey, ".."+string(filepath.Separator)) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(l.root, cleanKey), nil
+}
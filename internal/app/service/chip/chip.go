@@ -0,0 +1,121 @@
+package chip
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CodeLength длина номера транспондера FDX-B в десятичной записи ISO 11784
+	CodeLength = 15
+	// MaxNationalId наибольший национальный номер: под него отведено 38 бит
+	MaxNationalId = 1<<38 - 1
+	// TestCode код тестовых транспондеров
+	TestCode = 999
+
+	minManufacturerCode = 900
+)
+
+var ErrInvalidCode = errors.New("chip code must contain 15 digits in ISO 11784 format")
+
+// Code Номер чипа ISO 11784/11785: трёхзначный код страны (ISO 3166-1) или производителя
+// и двенадцатизначный национальный номер
+type Code struct {
+	Value            string
+	CountryCode      *int
+	ManufacturerCode *int
+	NationalId       uint64
+	Test             bool
+}
+
+// Normalize Удаление разделителей, с которыми считыватели выводят номер: "643 094100012345", "643-094100012345"
+func Normalize(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '.' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(code))
+}
+
+// Parse Разбор и проверка номера чипа
+func Parse(code string) (*Code, error) {
+	value := Normalize(code)
+	if len(value) != CodeLength {
+		return nil, ErrInvalidCode
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return nil, ErrInvalidCode
+		}
+	}
+
+	prefix, _ := strconv.Atoi(value[:3])
+	nationalId, _ := strconv.ParseUint(value[3:], 10, 64)
+	if prefix == 0 {
+		return nil, fmt.Errorf("chip code %s has empty country code", value)
+	}
+	if nationalId > MaxNationalId {
+		return nil, fmt.Errorf("chip code %s has national id greater than %d", value, uint64(MaxNationalId))
+	}
+
+	parsed := &Code{Value: value, NationalId: nationalId}
+	switch {
+	case prefix == TestCode:
+		parsed.Test = true
+	case prefix >= minManufacturerCode:
+		parsed.ManufacturerCode = &prefix
+	default:
+		parsed.CountryCode = &prefix
+	}
+	return parsed, nil
+}
+
+// CountryName Название страны по коду ISO 3166-1 или пустая строка для неизвестного кода
+func (c *Code) CountryName() string {
+	if c.CountryCode == nil {
+		return ""
+	}
+	return countries[*c.CountryCode]
+}
+
+// ManufacturerName Название производителя по коду ICAR или пустая строка для неизвестного кода
+func (c *Code) ManufacturerName() string {
+	if c.ManufacturerCode == nil {
+		return ""
+	}
+	return manufacturers[*c.ManufacturerCode]
+}
+
+var countries = map[int]string{
+	36:  "Australia",
+	112: "Belarus",
+	124: "Canada",
+	156: "China",
+	246: "Finland",
+	250: "France",
+	276: "Germany",
+	380: "Italy",
+	392: "Japan",
+	398: "Kazakhstan",
+	417: "Kyrgyzstan",
+	496: "Mongolia",
+	554: "New Zealand",
+	578: "Norway",
+	616: "Poland",
+	643: "Russia",
+	724: "Spain",
+	752: "Sweden",
+	826: "United Kingdom",
+	840: "United States",
+	860: "Uzbekistan",
+}
+
+var manufacturers = map[int]string{
+	977: "AVID",
+	981: "Datamars",
+	982: "Allflex",
+	985: "Destron Fearing",
+}
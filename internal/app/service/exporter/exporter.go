@@ -0,0 +1,234 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CSV     = "csv"
+	NDJSON  = "ndjson"
+	GeoJSON = "geojson"
+)
+
+// geometryColumn столбец CSV файла и поле NDJSON объекта, в которых выгружается геометрия записи
+const geometryColumn = "geometry"
+
+// listSeparator разделитель значений списка в столбце CSV файла, как при импорте
+const listSeparator = ";"
+
+var contentTypes = map[string]string{
+	CSV:     "text/csv; charset=utf-8",
+	NDJSON:  "application/x-ndjson",
+	GeoJSON: "application/geo+json",
+}
+
+// ContentType Значение заголовка Content-Type для формата
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// Writer Запись выгрузки. Values - значения столбцов в порядке, заданном при создании, geometry может быть nil.
+// Данные записываются в буфер и передаются дальше при Flush и Close
+type Writer interface {
+	Write(values []any, geometry *Geometry) error
+	Flush() error
+	Close() error
+}
+
+// NewWriter Создание записи в формате format. Заголовок CSV файла и начало FeatureCollection записываются
+// вместе с первой записью, поэтому до неё в w ничего не попадает
+func NewWriter(format string, w io.Writer, columns []string) (Writer, error) {
+	base := writer{dest: w, buf: bufio.NewWriter(w), columns: columns}
+	switch format {
+	case CSV:
+		return &csvWriter{writer: base}, nil
+	case NDJSON:
+		return &ndjsonWriter{writer: base}, nil
+	case GeoJSON:
+		return &geojsonWriter{writer: base}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %s", format)
+	}
+}
+
+type writer struct {
+	dest    io.Writer
+	buf     *bufio.Writer
+	columns []string
+	started bool
+}
+
+func (w *writer) Flush() error {
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	if flusher, ok := w.dest.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+type csvWriter struct {
+	writer
+	csv *csv.Writer
+}
+
+func (w *csvWriter) start() error {
+	w.started = true
+	w.csv = csv.NewWriter(w.buf)
+	return w.csv.Write(append(append([]string{}, w.columns...), geometryColumn))
+}
+
+func (w *csvWriter) Write(values []any, geometry *Geometry) error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, 0, len(values)+1)
+	for _, value := range values {
+		record = append(record, formatValue(value))
+	}
+	record = append(record, geometry.WKT())
+	return w.csv.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return w.writer.Flush()
+}
+
+func (w *csvWriter) Close() error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+type ndjsonWriter struct {
+	writer
+}
+
+func (w *ndjsonWriter) Write(values []any, geometry *Geometry) error {
+	w.started = true
+	object, err := marshalObject(append(append([]string{}, w.columns...), geometryColumn), append(append([]any{}, values...), geometry))
+	if err != nil {
+		return err
+	}
+	_, err = w.buf.Write(append(object, '\n'))
+	return err
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.Flush()
+}
+
+// geojsonWriter Выгрузка в виде одной FeatureCollection, значения столбцов записываются в properties
+type geojsonWriter struct {
+	writer
+}
+
+func (w *geojsonWriter) Write(values []any, geometry *Geometry) error {
+	separator := ","
+	if !w.started {
+		w.started = true
+		separator = `{"type":"FeatureCollection","features":[`
+	}
+
+	properties, err := marshalObject(w.columns, values)
+	if err != nil {
+		return err
+	}
+	geometryJson, err := json.Marshal(geometry)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w.buf, `%s{"type":"Feature","geometry":%s,"properties":%s}`, separator, geometryJson, properties)
+	return err
+}
+
+func (w *geojsonWriter) Close() error {
+	end := "]}"
+	if !w.started {
+		w.started = true
+		end = `{"type":"FeatureCollection","features":[]}`
+	}
+	if _, err := w.buf.WriteString(end); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// marshalObject JSON объект с полями в порядке столбцов
+func marshalObject(columns []string, values []any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(column)
+		value, err := json.Marshal(values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// formatValue Значение для столбца CSV файла. nil и пустые указатели записываются пустой строкой
+func formatValue(value any) string {
+	v := reflect.ValueOf(value)
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+
+	switch value := v.Interface().(type) {
+	case string:
+		return value
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case float32:
+		return strconv.FormatFloat(float64(value), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []int:
+		parts := make([]string, 0, len(value))
+		for _, item := range value {
+			parts = append(parts, strconv.Itoa(item))
+		}
+		return strings.Join(parts, listSeparator)
+	case []string:
+		return strings.Join(value, listSeparator)
+	}
+	return fmt.Sprint(v.Interface())
+}
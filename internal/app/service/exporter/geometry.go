@@ -0,0 +1,57 @@
+package exporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Geometry Геометрия GeoJSON. Координаты записываются в порядке долгота, широта
+type Geometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// Point Точка с координатами latitude, longitude
+func Point(latitude, longitude float64) *Geometry {
+	return &Geometry{Type: "Point", Coordinates: []float64{longitude, latitude}}
+}
+
+// Polygon Многоугольник без отверстий. Вершины передаются парами широта, долгота, контур замыкается автоматически
+func Polygon(vertices [][2]float64) *Geometry {
+	ring := make([][]float64, 0, len(vertices)+1)
+	for _, vertex := range vertices {
+		ring = append(ring, []float64{vertex[1], vertex[0]})
+	}
+	if len(ring) > 0 && (ring[0][0] != ring[len(ring)-1][0] || ring[0][1] != ring[len(ring)-1][1]) {
+		ring = append(ring, ring[0])
+	}
+	return &Geometry{Type: "Polygon", Coordinates: [][][]float64{ring}}
+}
+
+// WKT Представление геометрии в формате Well-Known Text для CSV файлов. Для nil - пустая строка
+func (g *Geometry) WKT() string {
+	if g == nil {
+		return ""
+	}
+
+	switch coordinates := g.Coordinates.(type) {
+	case []float64:
+		return fmt.Sprintf("POINT (%s)", wktPosition(coordinates))
+	case [][][]float64:
+		rings := make([]string, 0, len(coordinates))
+		for _, ring := range coordinates {
+			positions := make([]string, 0, len(ring))
+			for _, position := range ring {
+				positions = append(positions, wktPosition(position))
+			}
+			rings = append(rings, "("+strings.Join(positions, ", ")+")")
+		}
+		return fmt.Sprintf("POLYGON (%s)", strings.Join(rings, ", "))
+	}
+	return ""
+}
+
+func wktPosition(position []float64) string {
+	return strconv.FormatFloat(position[0], 'f', -1, 64) + " " + strconv.FormatFloat(position[1], 'f', -1, 64)
+}
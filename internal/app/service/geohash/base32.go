@@ -35,3 +35,13 @@ func (e *encoding) Encode(x uint64) string {
 
 // Base32Encoding with the Geohash alphabet.
 var base32encoding = newEncoding("0123456789bcdefghjkmnpqrstuvwxyz")
+
+// Decode string into bits of a 64-bit word. The string must contain only
+// characters of the encoding alphabet.
+func (e *encoding) Decode(s string) uint64 {
+	x := uint64(0)
+	for i := 0; i < len(s); i++ {
+		x = (x << 5) | uint64(e.decode[s[i]])
+	}
+	return x
+}
@@ -0,0 +1,215 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"it-planet-task/internal/app/model/input"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	CSV    = "csv"
+	NDJSON = "ndjson"
+)
+
+// animalTypesSeparator разделитель идентификаторов типов в столбце animalTypes CSV файла
+const animalTypesSeparator = ";"
+
+// maxLineSize наибольшая длина строки NDJSON файла
+const maxLineSize = 1 << 20
+
+var ErrTooManyRows = errors.New("too many rows")
+
+// Row Строка файла импорта. Err - ошибка разбора строки, остальные строки при этом разбираются дальше
+type Row struct {
+	Line   int
+	Animal *input.AnimalImport
+	Err    error
+}
+
+// FormatFromContentType Формат файла по заголовку Content-Type или пустая строка для неизвестного типа
+func FormatFromContentType(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "text/csv":
+		return CSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return NDJSON
+	}
+	return ""
+}
+
+// Parse Разбор файла формата format. Файл с числом строк больше maxRows отклоняется целиком, 0 - без ограничения
+func Parse(format string, r io.Reader, maxRows int) ([]Row, error) {
+	switch format {
+	case CSV:
+		return ParseCSV(r, maxRows)
+	case NDJSON:
+		return ParseNDJSON(r, maxRows)
+	default:
+		return nil, fmt.Errorf("unknown import format %s", format)
+	}
+}
+
+// ParseNDJSON Разбор файла, в каждой строке которого JSON объект животного как в POST /animals. Пустые строки пропускаются
+func ParseNDJSON(r io.Reader, maxRows int) ([]Row, error) {
+	rows := make([]Row, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if maxRows > 0 && len(rows) == maxRows {
+			return nil, ErrTooManyRows
+		}
+
+		animal := &input.AnimalImport{}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(animal); err != nil {
+			rows = append(rows, Row{Line: line, Err: err})
+			continue
+		}
+		rows = append(rows, Row{Line: line, Animal: animal})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// ParseCSV Разбор CSV файла с заголовком. Столбцы называются как поля JSON объекта животного,
+// типы животного в столбце animalTypes перечисляются через ";"
+func ParseCSV(r io.Reader, maxRows int) ([]Row, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		if _, ok := csvColumns[column]; !ok {
+			return nil, fmt.Errorf("unknown csv column %q", column)
+		}
+		header[i] = column
+	}
+	reader.FieldsPerRecord = len(header)
+
+	rows := make([]Row, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if maxRows > 0 && len(rows) == maxRows {
+			return nil, ErrTooManyRows
+		}
+		// позиция полей известна только для прочитанной записи, поэтому строка ошибки берётся из csv.ParseError
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, Row{Line: parseErr.Line, Err: parseErr.Err})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		animal := &input.AnimalImport{}
+		for i, value := range record {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if err = csvColumns[header[i]](animal, value); err != nil {
+				err = fmt.Errorf("%s: %w", header[i], err)
+				break
+			}
+		}
+		if err != nil {
+			rows = append(rows, Row{Line: line, Err: err})
+			continue
+		}
+		rows = append(rows, Row{Line: line, Animal: animal})
+	}
+
+	return rows, nil
+}
+
+// csvColumns Разбор значения столбца CSV файла в поле животного
+var csvColumns = map[string]func(animal *input.AnimalImport, value string) error{
+	"animalTypes": func(animal *input.AnimalImport, value string) error {
+		for _, part := range strings.Split(value, animalTypesSeparator) {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return errors.New("must be a list of ids separated by " + animalTypesSeparator)
+			}
+			animal.AnimalTypeIds = append(animal.AnimalTypeIds, id)
+		}
+		return nil
+	},
+	"weight":             floatColumn(func(animal *input.AnimalImport) **float32 { return &animal.Weight }),
+	"height":             floatColumn(func(animal *input.AnimalImport) **float32 { return &animal.Height }),
+	"length":             floatColumn(func(animal *input.AnimalImport) **float32 { return &animal.Length }),
+	"gender":             stringColumn(func(animal *input.AnimalImport) **string { return &animal.Gender }),
+	"chipperId":          intColumn(func(animal *input.AnimalImport) **int { return &animal.ChipperId }),
+	"chippingLocationId": intColumn(func(animal *input.AnimalImport) **int { return &animal.ChippingLocationId }),
+	"chippingDateTime":   timeColumn(func(animal *input.AnimalImport) **time.Time { return &animal.ChippingDateTime }),
+	"birthDateTime":      timeColumn(func(animal *input.AnimalImport) **time.Time { return &animal.BirthDateTime }),
+	"chipCode":           stringColumn(func(animal *input.AnimalImport) **string { return &animal.ChipCode }),
+}
+
+func floatColumn(field func(animal *input.AnimalImport) **float32) func(*input.AnimalImport, string) error {
+	return func(animal *input.AnimalImport, value string) error {
+		parsed, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		result := float32(parsed)
+		*field(animal) = &result
+		return nil
+	}
+}
+
+func intColumn(field func(animal *input.AnimalImport) **int) func(*input.AnimalImport, string) error {
+	return func(animal *input.AnimalImport, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		*field(animal) = &parsed
+		return nil
+	}
+}
+
+func stringColumn(field func(animal *input.AnimalImport) **string) func(*input.AnimalImport, string) error {
+	return func(animal *input.AnimalImport, value string) error {
+		*field(animal) = &value
+		return nil
+	}
+}
+
+func timeColumn(field func(animal *input.AnimalImport) **time.Time) func(*input.AnimalImport, string) error {
+	return func(animal *input.AnimalImport, value string) error {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errors.New("must be in ISO-8601 format")
+		}
+		*field(animal) = &parsed
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"it-planet-task/pkg/config"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	Outbox = "outbox"
+	Log    = "log"
+)
+
+const DefaultOutboxPath = "outbox/notifications.jsonl"
+
+// Message Уведомление для владельца аккаунта
+type Message struct {
+	To        string    `json:"to"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Notifier interface {
+	Send(message *Message) error
+}
+
+// Params Параметры доставки уведомлений
+type Params struct {
+	Type       string
+	OutboxPath string
+}
+
+// NewParamsFromConfig Чтение параметров из секции notifier конфигурационного файла
+func NewParamsFromConfig() Params {
+	return Params{
+		Type:       config.GetConfig().GetString("notifier.type"),
+		OutboxPath: config.GetConfig().GetString("notifier.outbox.path"),
+	}
+}
+
+// NewNotifier Создание notifier указанного типа. По умолчанию уведомления пишутся в outbox файл
+func NewNotifier(params Params) (Notifier, error) {
+	switch params.Type {
+	case "", Outbox:
+		if params.OutboxPath == "" {
+			params.OutboxPath = DefaultOutboxPath
+		}
+		return NewOutboxNotifier(params.OutboxPath), nil
+	case Log:
+		return &LogNotifier{}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type %s", params.Type)
+	}
+}
+
+// OutboxNotifier Запись уведомлений в файл, по одному JSON объекту на строку
+type OutboxNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewOutboxNotifier(path string) *OutboxNotifier {
+	return &OutboxNotifier{path: path}
+}
+
+func (o *OutboxNotifier) Send(message *Message) error {
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	err = os.MkdirAll(filepath.Dir(o.path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(o.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// LogNotifier Вывод уведомлений в лог сервиса
+type LogNotifier struct{}
+
+func (l *LogNotifier) Send(message *Message) error {
+	log.Printf("notification to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
@@ -0,0 +1,308 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"it-planet-task/pkg/config"
+	"it-planet-task/pkg/jwt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultJWKSRefresh    = time.Hour
+	DefaultEmailClaim     = "email"
+	DefaultFirstNameClaim = "given_name"
+	DefaultLastNameClaim  = "family_name"
+	DefaultRolesClaim     = "roles"
+
+	// minJWKSRefetch интервал, чаще которого набор ключей не перезапрашивается при токене с неизвестным kid
+	minJWKSRefetch = time.Minute
+	jwksTimeout    = 5 * time.Second
+)
+
+var (
+	ErrDisabled      = errors.New("external identity provider is not configured")
+	ErrMissingEmail  = errors.New("token does not contain email claim")
+	ErrMissingIssuer = errors.New("security.oidc.issuer is required when external identity provider is enabled")
+)
+
+type Oidc interface {
+	Enabled() bool
+	AccountPolicy() AccountPolicy
+	Authenticate(token string) (*Identity, error)
+}
+
+// Identity Пользователь внешнего провайдера, полученный из проверенного токена
+type Identity struct {
+	// ExternalId издатель и subject токена, по которым аккаунт связывается с провайдером
+	ExternalId string
+	Email      string
+	FirstName  string
+	LastName   string
+	// EmailVerified провайдер явно подтвердил email claim email_verified. Только такой пользователь может быть связан с существующим аккаунтом
+	EmailVerified bool
+	// Role роль, сопоставленная ролям провайдера. Пустая, если ни одна роль не сопоставлена
+	Role string
+}
+
+// AccountPolicy Правила сопоставления пользователя провайдера аккаунту сервиса.
+// LinkByEmail разрешает связать пользователя с существующим аккаунтом по подтверждённому email,
+// SyncRole разрешает обновлять роль аккаунта, уже связанного с провайдером. Оба правила по умолчанию выключены
+type AccountPolicy struct {
+	AutoProvision bool
+	DefaultRole   string
+	LinkByEmail   bool
+	SyncRole      bool
+}
+
+// RoleMapping Соответствие роли провайдера роли сервиса
+type RoleMapping struct {
+	External string
+	Role     string
+}
+
+// Params Параметры внешнего провайдера.
+// Ключи подписи читаются из файла JWKSFile или загружаются по JWKSURL и обновляются раз в JWKSRefresh.
+// RoleMapping сопоставляет роли провайдера из claim RolesClaim ролям сервиса, используется первое подходящее сопоставление
+type Params struct {
+	Enabled        bool
+	Issuer         string
+	Audience       string
+	JWKSFile       string
+	JWKSURL        string
+	JWKSRefresh    time.Duration
+	EmailClaim     string
+	FirstNameClaim string
+	LastNameClaim  string
+	RolesClaim     string
+	RoleMapping    []RoleMapping
+	DefaultRole    string
+	AutoProvision  bool
+	LinkByEmail    bool
+	SyncRole       bool
+}
+
+// NewParamsFromConfig Чтение параметров из секции security.oidc конфигурационного файла
+func NewParamsFromConfig() Params {
+	// viper приводит ключи к нижнему регистру, поэтому сопоставление задаётся списком пар, порядок которых задаёт приоритет
+	var roleMapping []RoleMapping
+	_ = config.GetConfig().UnmarshalKey("security.oidc.roleMapping", &roleMapping)
+
+	return Params{
+		Enabled:        config.GetConfig().GetBool("security.oidc.enabled"),
+		Issuer:         config.GetConfig().GetString("security.oidc.issuer"),
+		Audience:       config.GetConfig().GetString("security.oidc.audience"),
+		JWKSFile:       config.GetConfig().GetString("security.oidc.jwksFile"),
+		JWKSURL:        config.GetConfig().GetString("security.oidc.jwksUrl"),
+		JWKSRefresh:    config.GetConfig().GetDuration("security.oidc.jwksRefresh"),
+		EmailClaim:     config.GetConfig().GetString("security.oidc.claims.email"),
+		FirstNameClaim: config.GetConfig().GetString("security.oidc.claims.firstName"),
+		LastNameClaim:  config.GetConfig().GetString("security.oidc.claims.lastName"),
+		RolesClaim:     config.GetConfig().GetString("security.oidc.claims.roles"),
+		RoleMapping:    roleMapping,
+		DefaultRole:    config.GetConfig().GetString("security.oidc.defaultRole"),
+		AutoProvision:  config.GetConfig().GetBool("security.oidc.autoProvision"),
+		LinkByEmail:    config.GetConfig().GetBool("security.oidc.linkByEmail"),
+		SyncRole:       config.GetConfig().GetBool("security.oidc.syncRole"),
+	}
+}
+
+type OidcService struct {
+	params Params
+	client *http.Client
+
+	mu        sync.Mutex
+	keySet    *jwt.KeySet
+	fetchedAt time.Time
+}
+
+// NewOidcService Создание сервиса внешнего провайдера. Издатель обязателен, иначе принимались бы токены любого издателя с ключами из набора
+func NewOidcService(params Params) (Oidc, error) {
+	if params.Enabled && strings.TrimSpace(params.Issuer) == "" {
+		return nil, ErrMissingIssuer
+	}
+	if params.JWKSRefresh <= 0 {
+		params.JWKSRefresh = DefaultJWKSRefresh
+	}
+	if params.EmailClaim == "" {
+		params.EmailClaim = DefaultEmailClaim
+	}
+	if params.FirstNameClaim == "" {
+		params.FirstNameClaim = DefaultFirstNameClaim
+	}
+	if params.LastNameClaim == "" {
+		params.LastNameClaim = DefaultLastNameClaim
+	}
+	if params.RolesClaim == "" {
+		params.RolesClaim = DefaultRolesClaim
+	}
+	return &OidcService{params: params, client: &http.Client{Timeout: jwksTimeout}}, nil
+}
+
+func (o *OidcService) Enabled() bool {
+	return o.params.Enabled
+}
+
+// AccountPolicy Правила создания, связывания и синхронизации аккаунтов пользователей провайдера
+func (o *OidcService) AccountPolicy() AccountPolicy {
+	return AccountPolicy{
+		AutoProvision: o.params.AutoProvision,
+		DefaultRole:   o.params.DefaultRole,
+		LinkByEmail:   o.params.LinkByEmail,
+		SyncRole:      o.params.SyncRole,
+	}
+}
+
+// Authenticate Проверка токена провайдера и сопоставление его claims пользователю сервиса
+func (o *OidcService) Authenticate(token string) (*Identity, error) {
+	if !o.params.Enabled {
+		return nil, ErrDisabled
+	}
+
+	keySet, err := o.getKeySet(false)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, payload, err := jwt.ParseWithKeySet(token, keySet, o.params.Issuer, o.params.Audience)
+	if errors.Is(err, jwt.ErrUnknownKey) {
+		// провайдер мог сменить ключи раньше планового обновления
+		keySet, err = o.getKeySet(true)
+		if err != nil {
+			return nil, err
+		}
+		claims, payload, err = jwt.ParseWithKeySet(token, keySet, o.params.Issuer, o.params.Audience)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return o.identity(claims, payload)
+}
+
+func (o *OidcService) identity(claims *jwt.Claims, payload map[string]any) (*Identity, error) {
+	if claims.Subject == "" {
+		return nil, errors.New("token does not contain subject")
+	}
+
+	email := stringClaim(payload, o.params.EmailClaim)
+	if email == "" {
+		return nil, ErrMissingEmail
+	}
+
+	identity := &Identity{
+		ExternalId: claims.Issuer + "|" + claims.Subject,
+		Email:      email,
+		FirstName:  stringClaim(payload, o.params.FirstNameClaim),
+		LastName:   stringClaim(payload, o.params.LastNameClaim),
+		// email считается подтверждённым, только если провайдер явно передал email_verified: true
+		EmailVerified: claimValue(payload, "email_verified") == true,
+	}
+	if identity.FirstName == "" {
+		identity.FirstName = email
+	}
+	if identity.LastName == "" {
+		identity.LastName = email
+	}
+
+	externalRoles := make(map[string]bool)
+	for _, externalRole := range stringsClaim(payload, o.params.RolesClaim) {
+		externalRoles[externalRole] = true
+	}
+	for _, mapping := range o.params.RoleMapping {
+		if externalRoles[mapping.External] {
+			identity.Role = mapping.Role
+			break
+		}
+	}
+
+	return identity, nil
+}
+
+// getKeySet Набор ключей провайдера. Загружается при первом обращении и по истечении JWKSRefresh
+func (o *OidcService) getKeySet(force bool) (*jwt.KeySet, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	age := time.Since(o.fetchedAt)
+	if o.keySet != nil && age < o.params.JWKSRefresh && (!force || age < minJWKSRefetch) {
+		return o.keySet, nil
+	}
+
+	data, err := o.readJWKS()
+	if err == nil {
+		var keySet *jwt.KeySet
+		keySet, err = jwt.ParseJWKS(data)
+		if err == nil {
+			o.keySet = keySet
+			o.fetchedAt = time.Now()
+			return o.keySet, nil
+		}
+	}
+
+	// при недоступности провайдера продолжаем проверять токены ранее загруженными ключами
+	if o.keySet != nil {
+		return o.keySet, nil
+	}
+	return nil, fmt.Errorf("json web key set: %w", err)
+}
+
+func (o *OidcService) readJWKS() ([]byte, error) {
+	if o.params.JWKSFile != "" {
+		return os.ReadFile(o.params.JWKSFile)
+	}
+	if o.params.JWKSURL == "" {
+		return nil, errors.New("neither jwksFile nor jwksUrl is configured")
+	}
+
+	resp, err := o.client.Get(o.params.JWKSURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+// claimValue Значение claim. Имя через точку указывает на вложенный объект, например realm_access.roles
+func claimValue(payload map[string]any, name string) any {
+	var value any = payload
+	for _, part := range strings.Split(name, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[part]
+	}
+	return value
+}
+
+func stringClaim(payload map[string]any, name string) string {
+	value, _ := claimValue(payload, name).(string)
+	return strings.TrimSpace(value)
+}
+
+// stringsClaim Значение claim, которое может быть строкой, строкой через пробел или массивом строк
+func stringsClaim(payload map[string]any, name string) []string {
+	switch value := claimValue(payload, name).(type) {
+	case string:
+		return strings.Fields(value)
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"it-planet-task/pkg/config"
+	"strings"
+)
+
+const (
+	Bcrypt   = "bcrypt"
+	Argon2id = "argon2id"
+)
+
+const (
+	DefaultBcryptCost    = bcrypt.DefaultCost
+	DefaultArgon2Time    = 1
+	DefaultArgon2Memory  = 64 * 1024
+	DefaultArgon2Threads = 4
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+var ErrInvalidHash = errors.New("invalid password hash")
+
+type Password interface {
+	Hash(password string) (string, error)
+	Compare(storedPassword, password string) bool
+	IsHashed(storedPassword string) bool
+	NeedsRehash(storedPassword string) bool
+}
+
+// Params Параметры алгоритма хеширования паролей
+type Params struct {
+	Algorithm     string
+	BcryptCost    int
+	Argon2Time    uint32
+	Argon2Memory  uint32
+	Argon2Threads uint8
+}
+
+// NewParamsFromConfig Чтение параметров хеширования из секции security.password конфигурационного файла
+func NewParamsFromConfig() Params {
+	return Params{
+		Algorithm:     config.GetConfig().GetString("security.password.algorithm"),
+		BcryptCost:    config.GetConfig().GetInt("security.password.bcryptCost"),
+		Argon2Time:    config.GetConfig().GetUint32("security.password.argon2.time"),
+		Argon2Memory:  config.GetConfig().GetUint32("security.password.argon2.memory"),
+		Argon2Threads: uint8(config.GetConfig().GetUint("security.password.argon2.threads")),
+	}
+}
+
+type PasswordService struct {
+	params Params
+}
+
+func NewPasswordService(params Params) Password {
+	if params.Algorithm == "" {
+		params.Algorithm = Bcrypt
+	}
+	if params.BcryptCost == 0 {
+		params.BcryptCost = DefaultBcryptCost
+	}
+	if params.Argon2Time == 0 {
+		params.Argon2Time = DefaultArgon2Time
+	}
+	if params.Argon2Memory == 0 {
+		params.Argon2Memory = DefaultArgon2Memory
+	}
+	if params.Argon2Threads == 0 {
+		params.Argon2Threads = DefaultArgon2Threads
+	}
+	return &PasswordService{params: params}
+}
+
+// Hash Хеширование пароля настроенным алгоритмом
+func (p *PasswordService) Hash(password string) (string, error) {
+	switch p.params.Algorithm {
+	case Bcrypt:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), p.params.BcryptCost)
+		if err != nil {
+			return "", err
+		}
+		return string(hash), nil
+	case Argon2id:
+		salt := make([]byte, argon2SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, p.params.Argon2Time, p.params.Argon2Memory, p.params.Argon2Threads, argon2KeyLength)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version,
+			p.params.Argon2Memory, p.params.Argon2Time, p.params.Argon2Threads,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+	default:
+		return "", fmt.Errorf("unknown password hashing algorithm %s", p.params.Algorithm)
+	}
+}
+
+// Compare Сравнение пароля с сохранённым значением.
+// Сохранённое значение может быть хешем любого поддерживаемого алгоритма или паролем в открытом виде (старые записи)
+func (p *PasswordService) Compare(storedPassword, password string) bool {
+	switch algorithmOf(storedPassword) {
+	case Bcrypt:
+		return bcrypt.CompareHashAndPassword([]byte(storedPassword), []byte(password)) == nil
+	case Argon2id:
+		hash, err := parseArgon2Hash(storedPassword)
+		if err != nil {
+			return false
+		}
+		key := argon2.IDKey([]byte(password), hash.salt, hash.time, hash.memory, hash.threads, uint32(len(hash.key)))
+		return subtle.ConstantTimeCompare(key, hash.key) == 1
+	default:
+		return subtle.ConstantTimeCompare([]byte(storedPassword), []byte(password)) == 1
+	}
+}
+
+// IsHashed Проверка, что сохранённое значение является хешем, а не паролем в открытом виде
+func (p *PasswordService) IsHashed(storedPassword string) bool {
+	return algorithmOf(storedPassword) != ""
+}
+
+// NeedsRehash Проверка, что сохранённое значение нужно перехешировать текущими параметрами
+func (p *PasswordService) NeedsRehash(storedPassword string) bool {
+	algorithm := algorithmOf(storedPassword)
+	if algorithm != p.params.Algorithm {
+		return true
+	}
+
+	switch algorithm {
+	case Bcrypt:
+		cost, err := bcrypt.Cost([]byte(storedPassword))
+		return err != nil || cost != p.params.BcryptCost
+	case Argon2id:
+		hash, err := parseArgon2Hash(storedPassword)
+		return err != nil ||
+			hash.time != p.params.Argon2Time ||
+			hash.memory != p.params.Argon2Memory ||
+			hash.threads != p.params.Argon2Threads
+	}
+
+	return true
+}
+
+func algorithmOf(storedPassword string) string {
+	switch {
+	case strings.HasPrefix(storedPassword, "$2a$"),
+		strings.HasPrefix(storedPassword, "$2b$"),
+		strings.HasPrefix(storedPassword, "$2y$"):
+		return Bcrypt
+	case strings.HasPrefix(storedPassword, "$argon2id$"):
+		return Argon2id
+	}
+	return ""
+}
+
+type argon2Hash struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+// parseArgon2Hash Разбор хеша формата $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>
+func parseArgon2Hash(storedPassword string) (*argon2Hash, error) {
+	parts := strings.Split(storedPassword, "$")
+	if len(parts) != 6 {
+		return nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrInvalidHash
+	}
+
+	hash := &argon2Hash{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &hash.memory, &hash.time, &hash.threads); err != nil {
+		return nil, ErrInvalidHash
+	}
+
+	var err error
+	hash.salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, ErrInvalidHash
+	}
+	hash.key, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(hash.key) == 0 {
+		return nil, ErrInvalidHash
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,181 @@
+package permission
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/config"
+	"sort"
+	"strings"
+)
+
+// Права доступа. Роли из конфигурации ссылаются на эти имена,
+// допускается "*" (все права) и "<ресурс>:*" (все права на ресурс)
+const (
+	AnimalsRead      = "animals:read"
+	AnimalsCreate    = "animals:create"
+	AnimalsUpdate    = "animals:update"
+	AnimalsDelete    = "animals:delete"
+	AnimalsEditTypes = "animals:edit-types"
+	AnimalsShare     = "animals:share"
+	// AnimalsChip аккаунт с этим правом может быть указан чиппером животного
+	AnimalsChip = "animals:chip"
+	// ObservationsManage изменение и удаление чужих наблюдений. Свои наблюдения автор меняет всегда
+	ObservationsManage = "observations:manage"
+
+	AnimalTypesRead   = "animal-types:read"
+	AnimalTypesCreate = "animal-types:create"
+	AnimalTypesUpdate = "animal-types:update"
+	AnimalTypesDelete = "animal-types:delete"
+	// AnimalTypesProtect изменение признака охраняемого типа, от которого зависит огрубление координат
+	AnimalTypesProtect = "animal-types:protect"
+
+	VisitedLocationsRead   = "visited-locations:read"
+	VisitedLocationsCreate = "visited-locations:create"
+	VisitedLocationsUpdate = "visited-locations:update"
+	VisitedLocationsDelete = "visited-locations:delete"
+
+	LocationsRead   = "locations:read"
+	LocationsCreate = "locations:create"
+	LocationsUpdate = "locations:update"
+	LocationsDelete = "locations:delete"
+
+	AreasRead   = "areas:read"
+	AreasCreate = "areas:create"
+	AreasUpdate = "areas:update"
+	AreasDelete = "areas:delete"
+
+	// AccountsReadAny, AccountsUpdateAny, AccountsDeleteAny права на чужие аккаунты. Свой аккаунт доступен всегда
+	AccountsReadAny   = "accounts:read-any"
+	AccountsUpdateAny = "accounts:update-any"
+	AccountsDeleteAny = "accounts:delete-any"
+	AccountsSearch    = "accounts:search"
+	AccountsCreate    = "accounts:create"
+	// AccountsReassignAnimals передача животных отключаемого чиппера другому чипперу
+	AccountsReassignAnimals = "accounts:reassign-animals"
+
+	ApiKeysManage  = "api-keys:manage"
+	LockoutsManage = "lockouts:manage"
+	MetricsRead    = "metrics:read"
+	// LocationsReadExact точные координаты точек, связанных с животными охраняемых типов. Без права координаты огрубляются
+	LocationsReadExact = "locations:read-exact"
+	// AccountsReadEmail email чужих аккаунтов. Без права email скрывается
+	AccountsReadEmail = "accounts:read-email"
+	// SecurityEventsReadAny просмотр журнала аутентификаций чужих аккаунтов. Свой журнал доступен всегда
+	SecurityEventsReadAny = "security-events:read-any"
+
+	OrganizationsManage       = "organizations:manage"
+	OrganizationMembersManage = "organization-members:manage"
+	// OrganizationsAny доступ к данным всех организаций без выбора активной организации
+	OrganizationsAny = "organizations:any"
+
+	All = "*"
+)
+
+// platformPermissions Права, которые проверяются только по роли аккаунта.
+// Роль участника организации на них не влияет, иначе администратор организации получил бы права администратора платформы.
+// Типы животных не принадлежат организациям и общие для всех, поэтому их изменение тоже право платформы
+var platformPermissions = map[string]bool{
+	AnimalTypesCreate:       true,
+	AnimalTypesUpdate:       true,
+	AnimalTypesDelete:       true,
+	AnimalTypesProtect:      true,
+	AccountsReadAny:         true,
+	AccountsUpdateAny:       true,
+	AccountsDeleteAny:       true,
+	AccountsCreate:          true,
+	AccountsReassignAnimals: true,
+	ApiKeysManage:           true,
+	LockoutsManage:          true,
+	MetricsRead:             true,
+	SecurityEventsReadAny:   true,
+	AccountsReadEmail:       true,
+	OrganizationsManage:     true,
+	OrganizationsAny:        true,
+}
+
+// IsPlatform Проверка, что право относится к платформе, а не к данным организации
+func IsPlatform(permission string) bool {
+	return platformPermissions[permission]
+}
+
+// DefaultRoles Права ролей по умолчанию, если в конфигурации не задана секция security.roles
+var DefaultRoles = map[string][]string{
+	entity.AdminRole: {All},
+	entity.ChipperRole: {
+		AnimalsRead, AnimalsCreate, AnimalsUpdate, AnimalsEditTypes, AnimalsChip, AnimalsShare,
+		AnimalTypesRead, AnimalTypesCreate, AnimalTypesUpdate,
+		VisitedLocationsRead, VisitedLocationsCreate, VisitedLocationsUpdate,
+		LocationsRead, LocationsReadExact, LocationsCreate, LocationsUpdate,
+		AreasRead,
+	},
+	entity.UserRole: {
+		AnimalsRead, AnimalsCreate, AnimalsUpdate,
+		AnimalTypesRead,
+		VisitedLocationsRead,
+		LocationsRead,
+		AreasRead,
+	},
+}
+
+type Permission interface {
+	HasPermission(role string, permission string) bool
+	IsRole(role string) bool
+	Roles() []string
+}
+
+type PermissionService struct {
+	roles map[string]map[string]bool
+}
+
+// NewRolesFromConfig Чтение ролей и их прав из секции security.roles конфигурационного файла
+func NewRolesFromConfig() map[string][]string {
+	roles := config.GetConfig().GetStringMapStringSlice("security.roles")
+	if len(roles) == 0 {
+		return DefaultRoles
+	}
+
+	// viper приводит ключи к нижнему регистру, а роли хранятся в верхнем
+	r := make(map[string][]string, len(roles))
+	for role, permissions := range roles {
+		r[strings.ToUpper(role)] = permissions
+	}
+	return r
+}
+
+func NewPermissionService(roles map[string][]string) Permission {
+	p := &PermissionService{roles: make(map[string]map[string]bool, len(roles))}
+	for role, permissions := range roles {
+		p.roles[role] = make(map[string]bool, len(permissions))
+		for _, permission := range permissions {
+			p.roles[role][permission] = true
+		}
+	}
+	return p
+}
+
+func (p *PermissionService) HasPermission(role string, permission string) bool {
+	permissions, ok := p.roles[role]
+	if !ok {
+		return false
+	}
+
+	if permissions[All] || permissions[permission] {
+		return true
+	}
+
+	resource, _, found := strings.Cut(permission, ":")
+	return found && permissions[resource+":*"]
+}
+
+func (p *PermissionService) IsRole(role string) bool {
+	_, ok := p.roles[role]
+	return ok
+}
+
+func (p *PermissionService) Roles() []string {
+	roles := make([]string, 0, len(p.roles))
+	for role := range p.roles {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
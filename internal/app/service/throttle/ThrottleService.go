@@ -0,0 +1,231 @@
+package throttle
+
+import (
+	"it-planet-task/pkg/config"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	EmailKey = "email"
+	IpKey    = "ip"
+)
+
+const (
+	DefaultFreeAttempts     = 5
+	DefaultBaseDelay        = time.Second
+	DefaultMaxDelay         = 5 * time.Minute
+	DefaultLockoutThreshold = 20
+	DefaultLockoutDuration  = 15 * time.Minute
+	DefaultResetAfter       = time.Hour
+)
+
+type Throttle interface {
+	RetryAfter(keys ...Key) time.Duration
+	Failure(keys ...Key)
+	Success(key Key)
+	Lockouts() []Lockout
+	Clear(key Key) bool
+}
+
+// Key Ключ учёта неудачных попыток: email или IP адрес
+type Key struct {
+	Type  string
+	Value string
+}
+
+// Lockout Состояние ключа, для которого попытки входа временно запрещены
+type Lockout struct {
+	Key          Key
+	Failures     int
+	BlockedUntil time.Time
+	Locked       bool
+}
+
+// Params Параметры защиты от перебора паролей.
+// После FreeAttempts неудачных попыток каждая следующая откладывается на BaseDelay*2^n (но не более MaxDelay),
+// после LockoutThreshold попыток ключ блокируется на LockoutDuration.
+// Счётчик сбрасывается, если неудачных попыток не было в течение ResetAfter
+type Params struct {
+	FreeAttempts     int
+	BaseDelay        time.Duration
+	MaxDelay         time.Duration
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	ResetAfter       time.Duration
+}
+
+// NewParamsFromConfig Чтение параметров из секции security.throttle конфигурационного файла
+func NewParamsFromConfig() Params {
+	return Params{
+		FreeAttempts:     config.GetConfig().GetInt("security.throttle.freeAttempts"),
+		BaseDelay:        config.GetConfig().GetDuration("security.throttle.baseDelay"),
+		MaxDelay:         config.GetConfig().GetDuration("security.throttle.maxDelay"),
+		LockoutThreshold: config.GetConfig().GetInt("security.throttle.lockoutThreshold"),
+		LockoutDuration:  config.GetConfig().GetDuration("security.throttle.lockoutDuration"),
+		ResetAfter:       config.GetConfig().GetDuration("security.throttle.resetAfter"),
+	}
+}
+
+type attempts struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+	locked       bool
+}
+
+type ThrottleService struct {
+	params    Params
+	mu        sync.Mutex
+	attempts  map[Key]*attempts
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+func NewThrottleService(params Params) Throttle {
+	return newThrottleService(params, time.Now)
+}
+
+// NewThrottleServiceWithClock Конструктор с подменяемыми часами, используется в тестах
+func NewThrottleServiceWithClock(params Params, now func() time.Time) Throttle {
+	return newThrottleService(params, now)
+}
+
+func newThrottleService(params Params, now func() time.Time) *ThrottleService {
+	if params.FreeAttempts <= 0 {
+		params.FreeAttempts = DefaultFreeAttempts
+	}
+	if params.BaseDelay <= 0 {
+		params.BaseDelay = DefaultBaseDelay
+	}
+	if params.MaxDelay <= 0 {
+		params.MaxDelay = DefaultMaxDelay
+	}
+	if params.LockoutThreshold <= 0 {
+		params.LockoutThreshold = DefaultLockoutThreshold
+	}
+	if params.LockoutDuration <= 0 {
+		params.LockoutDuration = DefaultLockoutDuration
+	}
+	if params.ResetAfter <= 0 {
+		params.ResetAfter = DefaultResetAfter
+	}
+	return &ThrottleService{params: params, attempts: make(map[Key]*attempts), lastPrune: now(), now: now}
+}
+
+// RetryAfter Время, через которое можно повторить попытку входа. 0, если попытка разрешена
+func (t *ThrottleService) RetryAfter(keys ...Key) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var retryAfter time.Duration
+	for _, key := range keys {
+		a, ok := t.attempts[key]
+		if !ok {
+			continue
+		}
+		if wait := a.blockedUntil.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	return retryAfter
+}
+
+// Failure Учёт неудачной попытки входа
+func (t *ThrottleService) Failure(keys ...Key) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.prune(now)
+
+	for _, key := range keys {
+		a, ok := t.attempts[key]
+		if !ok || now.Sub(a.lastFailure) > t.params.ResetAfter {
+			a = &attempts{}
+			t.attempts[key] = a
+		}
+
+		a.failures++
+		a.lastFailure = now
+
+		if a.failures >= t.params.LockoutThreshold {
+			a.blockedUntil = now.Add(t.params.LockoutDuration)
+			a.locked = true
+		} else if a.failures > t.params.FreeAttempts {
+			delay := t.params.BaseDelay << (a.failures - t.params.FreeAttempts - 1)
+			if delay <= 0 || delay > t.params.MaxDelay {
+				delay = t.params.MaxDelay
+			}
+			a.blockedUntil = now.Add(delay)
+		}
+	}
+}
+
+// Success Сброс счётчика после успешного входа
+func (t *ThrottleService) Success(key Key) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.attempts, key)
+}
+
+func (t *ThrottleService) Lockouts() []Lockout {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	lockouts := make([]Lockout, 0)
+	for key, a := range t.attempts {
+		if a.blockedUntil.After(now) {
+			lockouts = append(lockouts, Lockout{Key: key, Failures: a.failures, BlockedUntil: a.blockedUntil, Locked: a.locked})
+		}
+	}
+
+	sort.Slice(lockouts, func(i, j int) bool {
+		return lockouts[i].BlockedUntil.Before(lockouts[j].BlockedUntil)
+	})
+	return lockouts
+}
+
+func (t *ThrottleService) Clear(key Key) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, ok := t.attempts[key]
+	delete(t.attempts, key)
+	return ok
+}
+
+// prune Удаление устаревших записей, чтобы учёт попыток не рос бесконечно
+func (t *ThrottleService) prune(now time.Time) {
+	if now.Sub(t.lastPrune) < t.params.ResetAfter {
+		return
+	}
+
+	for key, a := range t.attempts {
+		if now.Sub(a.lastFailure) > t.params.ResetAfter && !a.blockedUntil.After(now) {
+			delete(t.attempts, key)
+		}
+	}
+	t.lastPrune = now
+}
+
+func NewEmailKey(email string) Key {
+	return Key{Type: EmailKey, Value: strings.ToLower(strings.TrimSpace(email))}
+}
+
+func NewIpKey(ip string) Key {
+	return Key{Type: IpKey, Value: ip}
+}
+
+// RetryAfterSeconds Значение заголовка Retry-After, округлённое вверх до секунд
+func RetryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+}
@@ -0,0 +1,129 @@
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	ContentType = "image/jpeg"
+	DefaultSize = 256
+	// DefaultMaxPixels Предельное количество пикселей исходного изображения, 40 мегапикселей
+	DefaultMaxPixels = 40_000_000
+
+	jpegQuality = 80
+)
+
+var (
+	ErrUnsupportedImage = errors.New("image format is not supported")
+	ErrImageTooLarge    = errors.New("image dimensions exceed the limit")
+)
+
+// SupportedContentTypes Форматы изображений, для которых строятся миниатюры
+var SupportedContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Generate Построение JPEG миниатюры, вписанной в квадрат size x size с сохранением пропорций.
+// Изображения меньше size не увеличиваются.
+// Размеры читаются из заголовка до декодирования, изображения больше maxPixels не декодируются
+func Generate(r io.ReadSeeker, size int, maxPixels int) ([]byte, error) {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	if maxPixels <= 0 {
+		maxPixels = DefaultMaxPixels
+	}
+
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedImage
+		}
+		return nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width > maxPixels/config.Height {
+		return nil, ErrImageTooLarge
+	}
+
+	if _, err = r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	width, height := fit(bounds.Dx(), bounds.Dy(), size)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	downscale(dst, src)
+
+	buf := &bytes.Buffer{}
+	err = jpeg.Encode(buf, dst, &jpeg.Options{Quality: jpegQuality})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fit Размеры миниатюры, вписанной в квадрат size x size
+func fit(width, height, size int) (int, int) {
+	if width <= size && height <= size {
+		return maxInt(width, 1), maxInt(height, 1)
+	}
+	if width >= height {
+		return size, maxInt(height*size/width, 1)
+	}
+	return maxInt(width*size/height, 1), size
+}
+
+// downscale Уменьшение усреднением: каждый пиксель dst - среднее соответствующего прямоугольника src
+func downscale(dst *image.RGBA, src image.Image) {
+	srcBounds := src.Bounds()
+	dstBounds := dst.Bounds()
+
+	for y := 0; y < dstBounds.Dy(); y++ {
+		y0 := srcBounds.Min.Y + y*srcBounds.Dy()/dstBounds.Dy()
+		y1 := maxInt(srcBounds.Min.Y+(y+1)*srcBounds.Dy()/dstBounds.Dy(), y0+1)
+
+		for x := 0; x < dstBounds.Dx(); x++ {
+			x0 := srcBounds.Min.X + x*srcBounds.Dx()/dstBounds.Dx()
+			x1 := maxInt(srcBounds.Min.X+(x+1)*srcBounds.Dx()/dstBounds.Dx(), x0+1)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+
+			// JPEG не хранит прозрачность, поэтому прозрачные области накладываются на белый фон
+			background := 0xffff*count - a
+			dst.Set(x, y, color.RGBA64{
+				R: uint16((r + background) / count),
+				G: uint16((g + background) / count),
+				B: uint16((b + background) / count),
+				A: 0xffff,
+			})
+		}
+	}
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
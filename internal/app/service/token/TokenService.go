@@ -0,0 +1,173 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"it-planet-task/pkg/config"
+	"it-planet-task/pkg/jwt"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultAccessTokenTTL   = 15 * time.Minute
+	DefaultRefreshTokenTTL  = 30 * 24 * time.Hour
+	DefaultPasswordResetTTL = time.Hour
+
+	DefaultKeyEnv = "JWT_SIGNING_KEY"
+
+	opaqueTokenLength = 32
+)
+
+var (
+	ErrInvalidSubject = errors.New("invalid token subject")
+	ErrMissingKey     = errors.New("jwt signing key is not set")
+)
+
+var (
+	generatedKey     []byte
+	generatedKeyOnce sync.Once
+)
+
+type Token interface {
+	NewAccessToken(accountId int) (string, time.Time, error)
+	ParseAccessToken(accessToken string) (int, error)
+	NewRefreshToken() (string, time.Time, error)
+	NewPasswordResetToken() (string, time.Time, error)
+	HashToken(token string) string
+}
+
+// Params Параметры выдачи токенов
+type Params struct {
+	Key              []byte
+	Issuer           string
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	PasswordResetTTL time.Duration
+}
+
+// NewParamsFromConfig Чтение параметров из секции security.jwt конфигурационного файла.
+// Ключ подписи читается из переменной окружения security.jwt.keyEnv и не хранится в конфигурации.
+// Случайный ключ, действующий до перезапуска сервиса, генерируется только при явном security.jwt.allowRandomKey
+func NewParamsFromConfig() (Params, error) {
+	keyEnv := config.GetConfig().GetString("security.jwt.keyEnv")
+	if keyEnv == "" {
+		keyEnv = DefaultKeyEnv
+	}
+
+	params := Params{
+		Key:              []byte(os.Getenv(keyEnv)),
+		Issuer:           config.GetConfig().GetString("security.jwt.issuer"),
+		AccessTokenTTL:   config.GetConfig().GetDuration("security.jwt.accessTokenTTL"),
+		RefreshTokenTTL:  config.GetConfig().GetDuration("security.jwt.refreshTokenTTL"),
+		PasswordResetTTL: config.GetConfig().GetDuration("security.passwordReset.tokenTTL"),
+	}
+
+	if len(params.Key) == 0 {
+		if !config.GetConfig().GetBool("security.jwt.allowRandomKey") {
+			return Params{}, fmt.Errorf("%w: set environment variable %s", ErrMissingKey, keyEnv)
+		}
+		generatedKeyOnce.Do(func() {
+			log.Printf("%s is not set, using random key", keyEnv)
+			generatedKey = make([]byte, 32)
+			if _, err := rand.Read(generatedKey); err != nil {
+				log.Fatal(err)
+			}
+		})
+		params.Key = generatedKey
+	}
+
+	return params, nil
+}
+
+type TokenService struct {
+	params Params
+}
+
+func NewTokenService(params Params) Token {
+	if params.AccessTokenTTL <= 0 {
+		params.AccessTokenTTL = DefaultAccessTokenTTL
+	}
+	if params.RefreshTokenTTL <= 0 {
+		params.RefreshTokenTTL = DefaultRefreshTokenTTL
+	}
+	if params.PasswordResetTTL <= 0 {
+		params.PasswordResetTTL = DefaultPasswordResetTTL
+	}
+	return &TokenService{params: params}
+}
+
+// NewAccessToken Выдача подписанного access токена для аккаунта
+func (t *TokenService) NewAccessToken(accountId int) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(t.params.AccessTokenTTL)
+
+	accessToken, err := jwt.SignHS256(&jwt.Claims{
+		Subject:   strconv.Itoa(accountId),
+		Issuer:    t.params.Issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}, t.params.Key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return accessToken, expiresAt, nil
+}
+
+// ParseAccessToken Проверка access токена и получение id аккаунта
+func (t *TokenService) ParseAccessToken(accessToken string) (int, error) {
+	claims, err := jwt.ParseHS256(accessToken, t.params.Key, t.params.Issuer)
+	if err != nil {
+		return 0, err
+	}
+
+	accountId, err := strconv.Atoi(claims.Subject)
+	if err != nil || accountId <= 0 {
+		return 0, ErrInvalidSubject
+	}
+
+	return accountId, nil
+}
+
+// NewRefreshToken Генерация случайного refresh токена. В БД хранится только его хеш
+func (t *TokenService) NewRefreshToken() (string, time.Time, error) {
+	refreshToken, err := newOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return refreshToken, time.Now().Add(t.params.RefreshTokenTTL), nil
+}
+
+// NewPasswordResetToken Генерация одноразового токена сброса пароля. В БД хранится только его хеш
+func (t *TokenService) NewPasswordResetToken() (string, time.Time, error) {
+	resetToken, err := newOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return resetToken, time.Now().Add(t.params.PasswordResetTTL), nil
+}
+
+// HashToken Хеш случайного токена для хранения в БД
+func (t *TokenService) HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func newOpaqueToken() (string, error) {
+	b := make([]byte, opaqueTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -3,10 +3,12 @@ package AccountValidator
 import (
 	"fmt"
 	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
 	"it-planet-task/internal/app/validator"
 	"it-planet-task/pkg/errorHandler"
 	"net/http"
 	"net/mail"
+	"strings"
 )
 
 func ValidateAccountRegistration(account *entity.Account) *errorHandler.HttpErr {
@@ -32,15 +34,27 @@ func ValidateAccountRegistration(account *entity.Account) *errorHandler.HttpErr
 	return nil
 }
 
-func ValidateAccount(account *entity.Account) *errorHandler.HttpErr {
+func ValidateAccount(account *entity.Account, roles []string) *errorHandler.HttpErr {
 	httpErr := ValidateAccountRegistration(account)
 	if httpErr != nil {
 		return httpErr
 	}
 
-	if account.Role != entity.AdminRole && account.Role != entity.ChipperRole && account.Role != entity.UserRole {
-		return errorHandler.NewHttpErr(fmt.Sprintf("role must be in [%s, %s, %s]", entity.AdminRole, entity.ChipperRole, entity.UserRole), http.StatusBadRequest)
+	for _, role := range roles {
+		if account.Role == role {
+			return nil
+		}
 	}
 
+	return errorHandler.NewHttpErr(fmt.Sprintf("role must be in [%s]", strings.Join(roles, ", ")), http.StatusBadRequest)
+}
+
+func ValidateAnimalReassignmentInput(input *input.AnimalReassignment, fromAccountId int) *errorHandler.HttpErr {
+	if input.ChipperId == nil || *input.ChipperId <= 0 {
+		return errorHandler.NewHttpErr("chipperId must be greater than 0", http.StatusBadRequest)
+	}
+	if *input.ChipperId == fromAccountId {
+		return errorHandler.NewHttpErr("chipperId matches with account id", http.StatusBadRequest)
+	}
 	return nil
 }
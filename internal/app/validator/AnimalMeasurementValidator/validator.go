@@ -0,0 +1,62 @@
+package AnimalMeasurementValidator
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func ValidateMeasurementType(measurementType string) *errorHandler.HttpErr {
+	if _, ok := entity.MeasurementUnits[strings.ToUpper(measurementType)]; !ok {
+		return errorHandler.NewHttpErr(fmt.Sprintf("type must be in [%s, %s, %s]", entity.WeightMeasurement, entity.HeightMeasurement, entity.LengthMeasurement), http.StatusBadRequest)
+	}
+	return nil
+}
+
+// ValidateAnimalMeasurementInput Проверка замера. Замер не может быть раньше чипирования, позже смерти животного или в будущем
+func ValidateAnimalMeasurementInput(input *input.AnimalMeasurement, animal *response.Animal) *errorHandler.HttpErr {
+	if input.Type == nil {
+		return errorHandler.NewHttpErr("type is missing", http.StatusBadRequest)
+	}
+	httpErr := ValidateMeasurementType(*input.Type)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	if input.Value == nil {
+		return errorHandler.NewHttpErr("value is missing", http.StatusBadRequest)
+	}
+	if *input.Value <= 0 {
+		return errorHandler.NewHttpErr("value must be greater than 0", http.StatusBadRequest)
+	}
+
+	unit := entity.MeasurementUnits[strings.ToUpper(*input.Type)]
+	if input.Unit != nil && *input.Unit != unit {
+		return errorHandler.NewHttpErr(fmt.Sprintf("unit of %s must be %s", strings.ToLower(*input.Type), unit), http.StatusBadRequest)
+	}
+
+	if input.LocationPointId != nil && *input.LocationPointId <= 0 {
+		return errorHandler.NewHttpErr("locationPointId must be greater than 0", http.StatusBadRequest)
+	}
+
+	if input.MeasuredAt != nil {
+		if input.MeasuredAt.After(time.Now()) {
+			return errorHandler.NewHttpErr("measuredAt cant be in the future", http.StatusBadRequest)
+		}
+		if input.MeasuredAt.Before(animal.ChippingDateTime) {
+			return errorHandler.NewHttpErr("measuredAt cant be before chipping", http.StatusBadRequest)
+		}
+		if animal.DeathDateTime != nil && input.MeasuredAt.After(*animal.DeathDateTime) {
+			return errorHandler.NewHttpErr("measuredAt cant be after death", http.StatusBadRequest)
+		}
+	} else if animal.LifeStatus == entity.Dead {
+		return errorHandler.NewHttpErr("measuredAt is required for dead animal", http.StatusBadRequest)
+	}
+
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 	"it-planet-task/internal/app/model/entity"
 	"it-planet-task/internal/app/model/input"
 	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/service/chip"
 	"it-planet-task/pkg/errorHandler"
 	"net/http"
+	"time"
 )
 
 func ValidateLifeStatus(lifeStatus string) *errorHandler.HttpErr {
@@ -90,6 +92,9 @@ func ValidateAnimalCreateInput(input *input.Animal) *errorHandler.HttpErr {
 		return httpErr
 	}
 
+	if input.ChipCode != nil {
+		return ValidateChipCode(*input.ChipCode)
+	}
 	return nil
 }
 
@@ -136,3 +141,85 @@ func ValidateAnimalLocationPointUpdate(input *input.AnimalLocationPointUpdate) *
 	}
 	return nil
 }
+
+func ValidateAnimalParentsInput(input *input.AnimalParents) *errorHandler.HttpErr {
+	if input.MotherId != nil && *input.MotherId <= 0 {
+		return errorHandler.NewHttpErr("motherId must be greater than 0", http.StatusBadRequest)
+	}
+	if input.FatherId != nil && *input.FatherId <= 0 {
+		return errorHandler.NewHttpErr("fatherId must be greater than 0", http.StatusBadRequest)
+	}
+	if input.MotherId != nil && input.FatherId != nil && *input.MotherId == *input.FatherId {
+		return errorHandler.NewHttpErr("mother and father must be different animals", http.StatusBadRequest)
+	}
+	if input.BirthDateTime != nil && input.BirthDateTime.After(time.Now()) {
+		return errorHandler.NewHttpErr("birthDateTime cant be in the future", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// ValidateParent Проверка родителя животного animalId: пол родителя соответствует роли,
+// родитель родился раньше и был жив в момент рождения. Если дата рождения неизвестна,
+// родитель должен родиться раньше чипирования животного, которое не может быть раньше рождения.
+// Для нового животного animalId равен 0, а chippingDateTime - текущее время
+func ValidateParent(parent *response.Animal, gender string, animalId int, birthDateTime *time.Time, chippingDateTime time.Time) *errorHandler.HttpErr {
+	role := "mother"
+	if gender == entity.Male {
+		role = "father"
+	}
+
+	if parent.Id == animalId {
+		return errorHandler.NewHttpErr(fmt.Sprintf("animal cant be its own %s", role), http.StatusBadRequest)
+	}
+	if parent.Gender != gender {
+		return errorHandler.NewHttpErr(fmt.Sprintf("%s must have gender %s", role, gender), http.StatusBadRequest)
+	}
+
+	if birthDateTime == nil {
+		if parent.BirthDateTime != nil && !parent.BirthDateTime.Before(chippingDateTime) {
+			return errorHandler.NewHttpErr(fmt.Sprintf("%s must be born before animal chipping", role), http.StatusBadRequest)
+		}
+		return nil
+	}
+	if parent.BirthDateTime != nil && !parent.BirthDateTime.Before(*birthDateTime) {
+		return errorHandler.NewHttpErr(fmt.Sprintf("%s must be born before animal", role), http.StatusBadRequest)
+	}
+	if parent.DeathDateTime != nil && parent.DeathDateTime.Before(*birthDateTime) {
+		return errorHandler.NewHttpErr(fmt.Sprintf("%s must be alive at birth time", role), http.StatusBadRequest)
+	}
+	return nil
+}
+
+// ValidateChipCode Проверка номера чипа по ISO 11784. Разделители между цифрами допускаются
+func ValidateChipCode(code string) *errorHandler.HttpErr {
+	_, err := chip.Parse(code)
+	if err != nil {
+		return errorHandler.NewHttpErr(err.Error(), http.StatusBadRequest)
+	}
+	return nil
+}
+
+// ValidateAnimalImportInput Проверка строки импорта по правилам создания животного.
+// Дата чипирования может быть в прошлом, родители при импорте не указываются
+func ValidateAnimalImportInput(input *input.AnimalImport) *errorHandler.HttpErr {
+	httpErr := ValidateAnimalCreateInput(&input.Animal)
+	if httpErr != nil {
+		return httpErr
+	}
+
+	if input.MotherId != nil || input.FatherId != nil {
+		return errorHandler.NewHttpErr("motherId and fatherId are not supported in import", http.StatusBadRequest)
+	}
+	if input.ChippingDateTime != nil && input.ChippingDateTime.After(time.Now()) {
+		return errorHandler.NewHttpErr("chippingDateTime cant be in the future", http.StatusBadRequest)
+	}
+
+	chippingDateTime := time.Now()
+	if input.ChippingDateTime != nil {
+		chippingDateTime = *input.ChippingDateTime
+	}
+	if input.BirthDateTime != nil && input.BirthDateTime.After(chippingDateTime) {
+		return errorHandler.NewHttpErr("birthDateTime cant be after chipping", http.StatusBadRequest)
+	}
+	return nil
+}
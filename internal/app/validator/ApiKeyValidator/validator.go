@@ -0,0 +1,50 @@
+package ApiKeyValidator
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"strings"
+)
+
+func ValidateScope(scope string) *errorHandler.HttpErr {
+	for _, allowedScope := range entity.ApiKeyScopes {
+		if scope == allowedScope {
+			return nil
+		}
+	}
+	return errorHandler.NewHttpErr(fmt.Sprintf("scope must be in [%s]", strings.Join(entity.ApiKeyScopes, ", ")), http.StatusBadRequest)
+}
+
+func ValidateApiKeyInput(input *input.ApiKey) *errorHandler.HttpErr {
+	if input.AccountId == nil {
+		return errorHandler.NewHttpErr("accountId is missing", http.StatusBadRequest)
+	}
+	if *input.AccountId <= 0 {
+		return errorHandler.NewHttpErr("accountId must be greater than 0", http.StatusBadRequest)
+	}
+
+	if input.Name == nil || validator.IsStringEmpty(*input.Name) {
+		return errorHandler.NewHttpErr("name is empty", http.StatusBadRequest)
+	}
+
+	if len(input.Scopes) == 0 {
+		return errorHandler.NewHttpErr("scopes are empty", http.StatusBadRequest)
+	}
+	scopes := map[string]bool{}
+	for _, scope := range input.Scopes {
+		httpErr := ValidateScope(scope)
+		if httpErr != nil {
+			return httpErr
+		}
+		if scopes[scope] {
+			return errorHandler.NewHttpErr("duplicated scope", http.StatusBadRequest)
+		}
+		scopes[scope] = true
+	}
+
+	return nil
+}
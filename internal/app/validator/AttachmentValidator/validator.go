@@ -0,0 +1,33 @@
+package AttachmentValidator
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"unicode/utf8"
+)
+
+const MaxFileNameLength = 255
+
+// ValidateAttachment Проверка имени, размера и типа файла. Тип должен быть определён по содержимому
+func ValidateAttachment(attachment *entity.Attachment, maxSize int64) *errorHandler.HttpErr {
+	if attachment.FileName == "" {
+		return errorHandler.NewHttpErr("fileName is missing", http.StatusBadRequest)
+	}
+	if utf8.RuneCountInString(attachment.FileName) > MaxFileNameLength {
+		return errorHandler.NewHttpErr(fmt.Sprintf("fileName must be at most %d characters", MaxFileNameLength), http.StatusBadRequest)
+	}
+
+	if attachment.Size <= 0 {
+		return errorHandler.NewHttpErr("file is empty", http.StatusBadRequest)
+	}
+	if attachment.Size > maxSize {
+		return errorHandler.NewHttpErr(fmt.Sprintf("file must be at most %d bytes", maxSize), http.StatusRequestEntityTooLarge)
+	}
+
+	if !entity.AttachmentContentTypes[attachment.ContentType] {
+		return errorHandler.NewHttpErr(fmt.Sprintf("file type %s is not allowed", attachment.ContentType), http.StatusUnsupportedMediaType)
+	}
+	return nil
+}
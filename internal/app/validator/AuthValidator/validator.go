@@ -0,0 +1,52 @@
+package AuthValidator
+
+import (
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+)
+
+func ValidateLoginInput(input *input.Login) *errorHandler.HttpErr {
+	if input.Email == nil || validator.IsStringEmpty(*input.Email) {
+		return errorHandler.NewHttpErr("email is empty", http.StatusBadRequest)
+	}
+	if input.Password == nil || validator.IsStringEmpty(*input.Password) {
+		return errorHandler.NewHttpErr("password is empty", http.StatusBadRequest)
+	}
+	return nil
+}
+
+func ValidateRefreshTokenInput(input *input.RefreshToken) *errorHandler.HttpErr {
+	if input.RefreshToken == nil || validator.IsStringEmpty(*input.RefreshToken) {
+		return errorHandler.NewHttpErr("refreshToken is empty", http.StatusBadRequest)
+	}
+	return nil
+}
+
+func ValidatePasswordChangeInput(input *input.PasswordChange) *errorHandler.HttpErr {
+	if input.OldPassword == nil || validator.IsStringEmpty(*input.OldPassword) {
+		return errorHandler.NewHttpErr("oldPassword is empty", http.StatusBadRequest)
+	}
+	if input.NewPassword == nil || validator.IsStringEmpty(*input.NewPassword) {
+		return errorHandler.NewHttpErr("newPassword is empty", http.StatusBadRequest)
+	}
+	return nil
+}
+
+func ValidatePasswordResetRequestInput(input *input.PasswordResetRequest) *errorHandler.HttpErr {
+	if input.Email == nil || validator.IsStringEmpty(*input.Email) {
+		return errorHandler.NewHttpErr("email is empty", http.StatusBadRequest)
+	}
+	return nil
+}
+
+func ValidatePasswordResetInput(input *input.PasswordReset) *errorHandler.HttpErr {
+	if input.Token == nil || validator.IsStringEmpty(*input.Token) {
+		return errorHandler.NewHttpErr("token is empty", http.StatusBadRequest)
+	}
+	if input.NewPassword == nil || validator.IsStringEmpty(*input.NewPassword) {
+		return errorHandler.NewHttpErr("newPassword is empty", http.StatusBadRequest)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"it-planet-task/pkg/errorHandler"
 	"it-planet-task/pkg/paginator"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 )
@@ -35,6 +36,47 @@ func ValidateAndReturnPagination(from, size string) (*paginator.Pagination, *err
 	return pagination, nil
 }
 
+// ValidateAndReturnPage Разбор параметров страницы списка: from/size или курсоры after/before
+// и флаг total для подсчёта общего количества записей
+func ValidateAndReturnPage(q url.Values) (*paginator.Pagination, *errorHandler.HttpErr) {
+	pagination, httpErr := ValidateAndReturnPagination(q.Get("from"), q.Get("size"))
+	if httpErr != nil {
+		return nil, httpErr
+	}
+
+	if q.Get("after") != "" && q.Get("before") != "" {
+		return nil, errorHandler.NewHttpErr("after and before cant be used together", http.StatusBadRequest)
+	}
+	if (q.Get("after") != "" || q.Get("before") != "") && q.Get("from") != "" {
+		return nil, errorHandler.NewHttpErr("from cant be used together with cursor", http.StatusBadRequest)
+	}
+
+	if q.Get("after") != "" {
+		cursor, err := paginator.DecodeCursor(q.Get("after"))
+		if err != nil {
+			return nil, errorHandler.NewHttpErr("after is invalid cursor", http.StatusBadRequest)
+		}
+		pagination.After = cursor
+	}
+	if q.Get("before") != "" {
+		cursor, err := paginator.DecodeCursor(q.Get("before"))
+		if err != nil {
+			return nil, errorHandler.NewHttpErr("before is invalid cursor", http.StatusBadRequest)
+		}
+		pagination.Before = cursor
+	}
+
+	if q.Get("total") != "" {
+		withTotal, err := strconv.ParseBool(q.Get("total"))
+		if err != nil {
+			return nil, errorHandler.NewHttpErr("total must be boolean", http.StatusBadRequest)
+		}
+		pagination.WithTotal = withTotal
+	}
+
+	return pagination, nil
+}
+
 func ValidateAndReturnIntField(field, fieldName string) (int, *errorHandler.HttpErr) {
 	intField, err := strconv.Atoi(field)
 	if err != nil {
@@ -0,0 +1,68 @@
+package ObservationValidator
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const MaxTextLength = 4000
+
+// textSearchLanguages Языки поиска и соответствующие им конфигурации полнотекстового поиска
+var textSearchLanguages = map[string]string{
+	"ru":                     entity.RussianTextSearch,
+	"en":                     entity.EnglishTextSearch,
+	entity.RussianTextSearch: entity.RussianTextSearch,
+	entity.EnglishTextSearch: entity.EnglishTextSearch,
+}
+
+// ValidateAndReturnTextSearchConfig Конфигурация полнотекстового поиска для языка ru или en
+func ValidateAndReturnTextSearchConfig(language string) (string, *errorHandler.HttpErr) {
+	textSearchConfig, ok := textSearchLanguages[strings.ToLower(language)]
+	if !ok {
+		return "", errorHandler.NewHttpErr("language must be in [ru, en]", http.StatusBadRequest)
+	}
+	return textSearchConfig, nil
+}
+
+// ValidateObservationInput Проверка наблюдения. Посещение должно принадлежать животному,
+// время наблюдения не может быть раньше чипирования или в будущем
+func ValidateObservationInput(input *input.Observation, animal *response.Animal) *errorHandler.HttpErr {
+	if input.Text == nil || strings.TrimSpace(*input.Text) == "" {
+		return errorHandler.NewHttpErr("text is missing", http.StatusBadRequest)
+	}
+	if utf8.RuneCountInString(*input.Text) > MaxTextLength {
+		return errorHandler.NewHttpErr(fmt.Sprintf("text must be at most %d characters", MaxTextLength), http.StatusBadRequest)
+	}
+
+	if input.VisitedLocationPointId != nil {
+		if *input.VisitedLocationPointId <= 0 {
+			return errorHandler.NewHttpErr("visitedLocationPointId must be greater than 0", http.StatusBadRequest)
+		}
+
+		visited := false
+		for _, visitedLocationId := range animal.VisitedLocationsId {
+			visited = visited || visitedLocationId == *input.VisitedLocationPointId
+		}
+		if !visited {
+			return errorHandler.NewHttpErr(fmt.Sprintf("Animal does not have visited location point with id %d", *input.VisitedLocationPointId), http.StatusNotFound)
+		}
+	}
+
+	if input.ObservedAt != nil {
+		if input.ObservedAt.After(time.Now()) {
+			return errorHandler.NewHttpErr("observedAt cant be in the future", http.StatusBadRequest)
+		}
+		if input.ObservedAt.Before(animal.ChippingDateTime) {
+			return errorHandler.NewHttpErr("observedAt cant be before chipping", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
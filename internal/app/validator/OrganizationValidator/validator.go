@@ -0,0 +1,31 @@
+package OrganizationValidator
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"strings"
+)
+
+func ValidateOrganizationInput(input *input.Organization) *errorHandler.HttpErr {
+	if input.Name == nil || validator.IsStringEmpty(*input.Name) {
+		return errorHandler.NewHttpErr("name is empty", http.StatusBadRequest)
+	}
+	return nil
+}
+
+func ValidateMembershipInput(input *input.Membership, roles []string) *errorHandler.HttpErr {
+	if input.Role == nil || validator.IsStringEmpty(*input.Role) {
+		return errorHandler.NewHttpErr("role is empty", http.StatusBadRequest)
+	}
+
+	for _, role := range roles {
+		if *input.Role == role {
+			return nil
+		}
+	}
+
+	return errorHandler.NewHttpErr(fmt.Sprintf("role must be in [%s]", strings.Join(roles, ", ")), http.StatusBadRequest)
+}
@@ -0,0 +1,83 @@
+package TagValidator
+
+import (
+	"fmt"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const MaxTagLength = 64
+
+// MaxAnimalsPerRequest наибольшее число животных в одном запросе изменения меток
+const MaxAnimalsPerRequest = 1000
+
+// NormalizeTag Приведение имени метки к нижнему регистру с одиночными пробелами между словами.
+// Запятая запрещена, так как разделяет метки в параметре tags поиска
+func NormalizeTag(name string) (string, *errorHandler.HttpErr) {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" {
+		return "", errorHandler.NewHttpErr("tag cant be empty", http.StatusBadRequest)
+	}
+	if utf8.RuneCountInString(name) > MaxTagLength {
+		return "", errorHandler.NewHttpErr(fmt.Sprintf("tag must be at most %d characters", MaxTagLength), http.StatusBadRequest)
+	}
+	if strings.Contains(name, ",") {
+		return "", errorHandler.NewHttpErr("tag cant contain comma", http.StatusBadRequest)
+	}
+	return name, nil
+}
+
+// NormalizeTags Нормализация списка меток без повторов
+func NormalizeTags(names []string) ([]string, *errorHandler.HttpErr) {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		tag, httpErr := NormalizeTag(name)
+		if httpErr != nil {
+			return nil, httpErr
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
+
+// ValidateAnimalTagsInput Проверка группового изменения меток. Имена меток нормализуются на месте
+func ValidateAnimalTagsInput(input *input.AnimalTags) *errorHandler.HttpErr {
+	if len(input.AnimalIds) == 0 {
+		return errorHandler.NewHttpErr("animalIds is missing", http.StatusBadRequest)
+	}
+	if len(input.AnimalIds) > MaxAnimalsPerRequest {
+		return errorHandler.NewHttpErr(fmt.Sprintf("animalIds must contain at most %d animals", MaxAnimalsPerRequest), http.StatusBadRequest)
+	}
+	for _, animalId := range input.AnimalIds {
+		if animalId <= 0 {
+			return errorHandler.NewHttpErr("animalIds must be greater than 0", http.StatusBadRequest)
+		}
+	}
+
+	var httpErr *errorHandler.HttpErr
+	if input.Add, httpErr = NormalizeTags(input.Add); httpErr != nil {
+		return httpErr
+	}
+	if input.Remove, httpErr = NormalizeTags(input.Remove); httpErr != nil {
+		return httpErr
+	}
+	if len(input.Add) == 0 && len(input.Remove) == 0 {
+		return errorHandler.NewHttpErr("add or remove is missing", http.StatusBadRequest)
+	}
+
+	for _, added := range input.Add {
+		for _, removed := range input.Remove {
+			if added == removed {
+				return errorHandler.NewHttpErr(fmt.Sprintf("tag %s cant be added and removed at once", added), http.StatusBadRequest)
+			}
+		}
+	}
+	return nil
+}
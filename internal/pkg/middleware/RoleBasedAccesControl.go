@@ -3,27 +3,39 @@ package middleware
 import (
 	"github.com/gin-gonic/gin"
 	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/service/permission"
 	"net/http"
+	"sync"
 )
 
-func AdminRequired(c *gin.Context) {
-	authorizedAccountAny, _ := c.Get("account")
-	authorizedAccount := authorizedAccountAny.(*entity.Account)
-	if authorizedAccount.Role != entity.AdminRole {
-		c.AbortWithStatusJSON(http.StatusForbidden, "Only admin can access this endpoint")
-		return
-	}
+var (
+	permissionService     permission.Permission
+	permissionServiceOnce sync.Once
+)
 
-	c.Next()
+// GetPermissionService Сервис прав доступа, построенный по конфигурации при первом обращении
+func GetPermissionService() permission.Permission {
+	permissionServiceOnce.Do(func() {
+		permissionService = permission.NewPermissionService(permission.NewRolesFromConfig())
+	})
+	return permissionService
 }
 
-func AdminOrChipperRequired(c *gin.Context) {
-	authorizedAccountAny, _ := c.Get("account")
-	authorizedAccount := authorizedAccountAny.(*entity.Account)
-	if authorizedAccount.Role != entity.AdminRole && authorizedAccount.Role != entity.ChipperRole {
-		c.AbortWithStatusJSON(http.StatusForbidden, "Only admin or chipper can access this endpoint")
-		return
-	}
+// Require Проверка, что роль авторизованного аккаунта имеет право доступа requiredPermission.
+// Если выбрана активная организация, то права на данные проверяются по роли участника организации
+func Require(requiredPermission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorizedAccountAny, _ := c.Get("account")
+		authorizedAccount := authorizedAccountAny.(*entity.Account)
+		role := authorizedAccount.Role
+		if organizationRole, ok := c.Get("organizationRole"); ok && !permission.IsPlatform(requiredPermission) {
+			role = organizationRole.(string)
+		}
+		if !GetPermissionService().HasPermission(role, requiredPermission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, "Permission "+requiredPermission+" required to access this endpoint")
+			return
+		}
 
-	c.Next()
+		c.Next()
+	}
 }
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"it-planet-task/helpers"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/accountcache"
+	"it-planet-task/internal/app/service/password"
+	"sync"
+)
+
+var (
+	accountCache     accountcache.AccountCache
+	accountCacheOnce sync.Once
+
+	accountService     service.Account
+	accountServiceOnce sync.Once
+)
+
+// GetAccountCache Кеш аутентифицированных аккаунтов. Тот же экземпляр передаётся в AccountService для инвалидации
+func GetAccountCache() accountcache.AccountCache {
+	accountCacheOnce.Do(func() {
+		accountCache = accountcache.NewAccountCacheService(accountcache.NewParamsFromConfig())
+	})
+	return accountCache
+}
+
+func getAccountService() service.Account {
+	accountServiceOnce.Do(func() {
+		accountRepo := repository.NewAccountRepository(helpers.GetConnectionOrCreateAndGet())
+		passwordService := password.NewPasswordService(password.NewParamsFromConfig())
+		accountService = service.NewAccountService(accountRepo, passwordService, GetAccountCache())
+	})
+	return accountService
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"it-planet-task/helpers"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service"
+	"net/http"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+func DecodeApiKey(c *gin.Context) (string, bool) {
+	key := c.GetHeader(apiKeyHeader)
+	return key, key != ""
+}
+
+func GetApiKey(c *gin.Context) (*entity.ApiKey, error) {
+	key, ok := DecodeApiKey(c)
+	if !ok {
+		return nil, errors.New("api key is missing")
+	}
+
+	apiKeyRepo := repository.NewApiKeyRepository(helpers.GetConnectionOrCreateAndGet())
+	apiKeyService := service.NewApiKeyService(apiKeyRepo)
+
+	return apiKeyService.Authenticate(key)
+}
+
+// ApiKeyAuth middleware для аутентификации машинных клиентов по API ключу
+func ApiKeyAuth(c *gin.Context) {
+	apiKey, err := GetApiKey(c)
+	if err != nil || apiKey.Account.Id == 0 {
+		if _, ok := DecodeApiKey(c); ok {
+			RecordAuthEvent(c, entity.AuthFailureEvent, entity.ApiKeyAuthMethod, nil, "", "invalid api key")
+		}
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	if !apiKey.Account.IsActive() {
+		RecordAuthEvent(c, entity.AuthFailureEvent, entity.ApiKeyAuthMethod, &apiKey.Account, "", "account is disabled")
+		c.AbortWithStatusJSON(http.StatusForbidden, "Account is disabled")
+		return
+	}
+
+	RecordAuthEvent(c, entity.AuthSuccessEvent, entity.ApiKeyAuthMethod, &apiKey.Account, "", "")
+
+	c.Set("account", &apiKey.Account)
+	c.Set("apiKey", apiKey)
+	if !ResolveTenant(c, &apiKey.Account) {
+		return
+	}
+	c.Next()
+}
+
+// ScopeRequired Проверка, что API ключ, которым аутентифицирован запрос, имеет нужный scope.
+// Запросы, аутентифицированные паролем или токеном, не ограничиваются
+func ScopeRequired(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKeyAny, ok := c.Get("apiKey")
+		if !ok {
+			c.Next()
+			return
+		}
+
+		apiKey := apiKeyAny.(*entity.ApiKey)
+		if !apiKey.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, "Api key does not have scope "+scope)
+			return
+		}
+
+		c.Next()
+	}
+}
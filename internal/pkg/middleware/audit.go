@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"it-planet-task/helpers"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/audit"
+	"sync"
+)
+
+var (
+	auditService     audit.Audit
+	auditServiceOnce sync.Once
+)
+
+// GetAuditService Журнал аутентификаций, в который пишут middleware и вход по паролю
+func GetAuditService() audit.Audit {
+	auditServiceOnce.Do(func() {
+		securityEventRepo := repository.NewSecurityEventRepository(helpers.GetConnectionOrCreateAndGet())
+		auditService = audit.NewAuditService(securityEventRepo, audit.NewParamsFromConfig())
+	})
+	return auditService
+}
+
+// RecordAuthEvent Запись результата аутентификации запроса.
+// Если аккаунт не известен, он определяется по email, чтобы неудачные попытки попадали в историю владельца
+func RecordAuthEvent(c *gin.Context, eventType, method string, account *entity.Account, email, reason string) {
+	securityEvent := &entity.SecurityEvent{
+		Email:     email,
+		Type:      eventType,
+		Method:    method,
+		Reason:    reason,
+		Ip:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+
+	if account != nil && account.Id != 0 {
+		securityEvent.AccountId = &account.Id
+		securityEvent.Email = account.Email
+	} else if email != "" {
+		accountResponse, err := getAccountService().GetByEmail(&entity.Account{Email: email})
+		if err == nil && accountResponse.Id != 0 {
+			securityEvent.AccountId = &accountResponse.Id
+		}
+	}
+
+	GetAuditService().Record(securityEvent)
+}
@@ -3,10 +3,8 @@ package middleware
 import (
 	"errors"
 	"github.com/gin-gonic/gin"
-	"it-planet-task/helpers"
 	"it-planet-task/internal/app/model/entity"
-	"it-planet-task/internal/app/repository"
-	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/throttle"
 	"net/http"
 )
 
@@ -16,33 +14,66 @@ func DecodeCredentials(c *gin.Context) (string, string, bool) {
 }
 
 func GetAccountByCreds(c *gin.Context) (*entity.Account, error) {
-	login, password, ok := DecodeCredentials(c)
+	login, pass, ok := DecodeCredentials(c)
 	if !ok {
 		return nil, errors.New("")
 	}
 
+	cachedAccount, ok := GetAccountCache().GetByCreds(login, pass)
+	if ok {
+		return cachedAccount, nil
+	}
+
 	account := &entity.Account{
 		Email:    login,
-		Password: password,
+		Password: pass,
 	}
 
-	accountRepo := repository.NewAccountRepository(helpers.GetConnectionOrCreateAndGet())
-	accountService := service.NewAccountService(accountRepo)
-
-	return accountService.GetByCreds(account), nil
+	generation := GetAccountCache().Generation()
+	account = getAccountService().GetByCreds(account)
+	if account.Id != 0 {
+		GetAccountCache().SetByCreds(login, pass, account, generation)
+	}
 
+	return account, nil
 }
 
 // BasicAuth middleware для basic auth
 func BasicAuth(c *gin.Context) {
+	login, _, ok := DecodeCredentials(c)
+	emailKey := throttle.NewEmailKey(login)
+	ipKey := throttle.NewIpKey(c.ClientIP())
+
+	retryAfter := GetLoginThrottle().RetryAfter(emailKey, ipKey)
+	if retryAfter > 0 {
+		RecordAuthEvent(c, entity.AuthFailureEvent, entity.BasicAuthMethod, nil, login, "too many failed attempts")
+		c.Header("Retry-After", throttle.RetryAfterSeconds(retryAfter))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, "Too many failed login attempts")
+		return
+	}
+
 	acc, err := GetAccountByCreds(c)
 
 	if err != nil || acc.Id == 0 {
+		if ok {
+			GetLoginThrottle().Failure(emailKey, ipKey)
+			RecordAuthEvent(c, entity.AuthFailureEvent, entity.BasicAuthMethod, nil, login, "invalid credentials")
+		}
 		c.AbortWithStatus(http.StatusUnauthorized)
 		c.Next()
 		return
 	}
 
+	GetLoginThrottle().Success(emailKey)
+	if !acc.IsActive() {
+		RecordAuthEvent(c, entity.AuthFailureEvent, entity.BasicAuthMethod, acc, login, "account is disabled")
+		c.AbortWithStatusJSON(http.StatusForbidden, "Account is disabled")
+		return
+	}
+	RecordAuthEvent(c, entity.AuthSuccessEvent, entity.BasicAuthMethod, acc, login, "")
 	c.Set("account", acc)
+	if !ResolveTenant(c, acc) {
+		return
+	}
 	c.Next()
 }
@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"it-planet-task/helpers"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/token"
+	"it-planet-task/pkg/errorHandler"
+	"net/http"
+	"strings"
+)
+
+const bearerPrefix = "Bearer "
+
+func DecodeBearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader("Authorization")
+	if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(bearerPrefix):]), true
+}
+
+func GetAccountByAccessToken(c *gin.Context) (*entity.Account, error) {
+	accessToken, ok := DecodeBearerToken(c)
+	if !ok {
+		return nil, errors.New("bearer token is missing")
+	}
+
+	if isExternalToken(accessToken) {
+		return getAccountByExternalToken(accessToken)
+	}
+
+	tokenParams, err := token.NewParamsFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	accountId, err := token.NewTokenService(tokenParams).ParseAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	cachedAccount, ok := GetAccountCache().GetById(accountId)
+	if ok {
+		return cachedAccount, nil
+	}
+
+	generation := GetAccountCache().Generation()
+	accountRepo := repository.NewAccountRepository(helpers.GetConnectionOrCreateAndGet())
+	account, err := accountRepo.Get(accountId)
+	if err != nil {
+		return nil, err
+	}
+	GetAccountCache().SetById(account, generation)
+
+	return account, nil
+}
+
+// BearerAuth middleware для аутентификации по access токену
+func BearerAuth(c *gin.Context) {
+	method := entity.BearerAuthMethod
+	accessToken, ok := DecodeBearerToken(c)
+	if ok && isExternalToken(accessToken) {
+		method = entity.OidcAuthMethod
+	}
+
+	acc, err := GetAccountByAccessToken(c)
+	if err != nil || acc.Id == 0 {
+		var httpErr *errorHandler.HttpErr
+		if errors.As(err, &httpErr) {
+			RecordAuthEvent(c, entity.AuthFailureEvent, method, nil, "", httpErr.Err.Error())
+			c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+			return
+		}
+		if ok {
+			RecordAuthEvent(c, entity.AuthFailureEvent, method, nil, "", "invalid access token")
+		}
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	if !acc.IsActive() {
+		RecordAuthEvent(c, entity.AuthFailureEvent, method, acc, "", "account is disabled")
+		c.AbortWithStatusJSON(http.StatusForbidden, "Account is disabled")
+		return
+	}
+
+	RecordAuthEvent(c, entity.AuthSuccessEvent, method, acc, "", "")
+	c.Set("account", acc)
+	if !ResolveTenant(c, acc) {
+		return
+	}
+	c.Next()
+}
+
+// Auth middleware, принимающий API ключ, bearer токен или basic auth
+func Auth(c *gin.Context) {
+	if _, ok := DecodeApiKey(c); ok {
+		ApiKeyAuth(c)
+		return
+	}
+
+	if _, ok := DecodeBearerToken(c); ok {
+		BearerAuth(c)
+		return
+	}
+
+	BasicAuth(c)
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"it-planet-task/internal/app/service/throttle"
+	"sync"
+)
+
+var (
+	loginThrottle     throttle.Throttle
+	loginThrottleOnce sync.Once
+
+	passwordResetThrottle     throttle.Throttle
+	passwordResetThrottleOnce sync.Once
+)
+
+// GetLoginThrottle Общий для всех способов входа по паролю учёт неудачных попыток
+func GetLoginThrottle() throttle.Throttle {
+	loginThrottleOnce.Do(func() {
+		loginThrottle = throttle.NewThrottleService(throttle.NewParamsFromConfig())
+	})
+	return loginThrottle
+}
+
+// GetPasswordResetThrottle Учёт запросов сброса пароля по email и IP адресу. Параметры общие с защитой входа,
+// но счётчики отдельные, чтобы запросы сброса не блокировали вход
+func GetPasswordResetThrottle() throttle.Throttle {
+	passwordResetThrottleOnce.Do(func() {
+		passwordResetThrottle = throttle.NewThrottleService(throttle.NewParamsFromConfig())
+	})
+	return passwordResetThrottle
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/service/oidc"
+	"it-planet-task/pkg/jwt"
+	"log"
+	"sync"
+)
+
+var (
+	oidcService     oidc.Oidc
+	oidcServiceErr  error
+	oidcServiceOnce sync.Once
+)
+
+// InitOidcService Создание сервиса внешнего провайдера. Вызывается при запуске, чтобы ошибка конфигурации не проявилась на первом запросе
+func InitOidcService() error {
+	oidcServiceOnce.Do(func() {
+		oidcService, oidcServiceErr = oidc.NewOidcService(oidc.NewParamsFromConfig())
+	})
+	return oidcServiceErr
+}
+
+// GetOidcService Проверка токенов внешнего провайдера. Набор ключей провайдера общий для всех запросов
+func GetOidcService() oidc.Oidc {
+	if err := InitOidcService(); err != nil {
+		log.Fatal(err)
+	}
+	return oidcService
+}
+
+// isExternalToken Токены сервиса подписываются HS256, токены провайдера асимметричными алгоритмами
+func isExternalToken(accessToken string) bool {
+	if !GetOidcService().Enabled() {
+		return false
+	}
+
+	header, err := jwt.ParseHeader(accessToken)
+	if err != nil {
+		return false
+	}
+	return header.Algorithm == jwt.RS256 || header.Algorithm == jwt.ES256
+}
+
+// getAccountByExternalToken Аккаунт пользователя провайдера, при необходимости созданный при первом входе
+func getAccountByExternalToken(accessToken string) (*entity.Account, error) {
+	identity, err := GetOidcService().Authenticate(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	account, httpErr := getAccountService().GetByExternalIdentity(identity, GetOidcService().AccountPolicy())
+	if httpErr != nil {
+		return nil, httpErr
+	}
+	return account, nil
+}
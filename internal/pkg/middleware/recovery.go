@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recovery Восстановление после паники обработчика с ответом 500.
+// http.ErrAbortHandler пробрасывается серверу, чтобы тот оборвал соединение: так клиент потоковой выгрузки
+// видит прерванную передачу, а не завершённый неполный файл
+func Recovery(c *gin.Context) {
+	defer func() {
+		err := recover()
+		if err == nil {
+			return
+		}
+		if err == http.ErrAbortHandler {
+			panic(err)
+		}
+
+		log.Printf("panic recovered: %v\n%s", err, debug.Stack())
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}()
+	c.Next()
+}
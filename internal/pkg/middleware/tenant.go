@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"it-planet-task/helpers"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service/permission"
+	"it-planet-task/internal/app/validator"
+	"net/http"
+)
+
+// OrganizationHeader Заголовок с id активной организации
+const OrganizationHeader = "X-Organization-Id"
+
+// ResolveTenant Определение активной организации запроса по заголовку X-Organization-Id.
+// Аккаунт должен быть участником организации, кроме аккаунтов с правом доступа ко всем организациям.
+// Без заголовка доступно только общее пространство, а аккаунтам с правом organizations:any - данные всех организаций
+func ResolveTenant(c *gin.Context, account *entity.Account) bool {
+	unrestricted := GetPermissionService().HasPermission(account.Role, permission.OrganizationsAny)
+	scope := &filter.TenantScope{Unrestricted: unrestricted}
+
+	header := c.GetHeader(OrganizationHeader)
+	if header != "" {
+		organizationId, httpErr := validator.ValidateAndReturnId(header, OrganizationHeader)
+		if httpErr != nil {
+			c.AbortWithStatusJSON(httpErr.StatusCode, httpErr.Err.Error())
+			return false
+		}
+
+		organizationRepo := repository.NewOrganizationRepository(helpers.GetConnectionOrCreateAndGet())
+		membership, err := organizationRepo.GetMembership(organizationId, account.Id)
+		if err == nil {
+			c.Set("organizationRole", membership.Role)
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+			return false
+		} else if !unrestricted {
+			c.AbortWithStatusJSON(http.StatusForbidden, "Account is not a member of organization")
+			return false
+		} else if _, err = organizationRepo.Get(organizationId); err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, "Organization does not exists")
+			return false
+		}
+
+		scope = &filter.TenantScope{OrganizationId: &organizationId}
+	}
+
+	c.Set("tenant", scope)
+	return true
+}
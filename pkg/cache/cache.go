@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats Статистика обращений к кешу
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+}
+
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache Потокобезопасный кеш с ограничением времени жизни и количества записей
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	maxSize int
+	entries map[K]entry[V]
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+
+	now func() time.Time
+}
+
+func New[K comparable, V any](ttl time.Duration, maxSize int) *Cache[K, V] {
+	return &Cache[K, V]{ttl: ttl, maxSize: maxSize, entries: make(map[K]entry[V]), now: time.Now}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expiresAt) {
+		if ok {
+			delete(c.entries, key)
+			c.evictions.Add(1)
+		}
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+
+	c.hits.Add(1)
+	return e.value, true
+}
+
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && c.maxSize > 0 && len(c.entries) >= c.maxSize {
+		c.evictExpired()
+		if len(c.entries) >= c.maxSize {
+			// кеш заполнен действующими записями, освобождаем место под новую
+			for k := range c.entries {
+				delete(c.entries, k)
+				c.evictions.Add(1)
+				break
+			}
+		}
+	}
+
+	c.entries[key] = entry[V]{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// DeleteFunc Удаление всех записей, для которых match возвращает true
+func (c *Cache[K, V]) DeleteFunc(match func(key K, value V) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, e := range c.entries {
+		if match(k, e.value) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	size := len(c.entries)
+	c.mu.Unlock()
+
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Size:      size,
+	}
+}
+
+func (c *Cache[K, V]) evictExpired() {
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expiresAt) {
+			delete(c.entries, k)
+			c.evictions.Add(1)
+		}
+	}
+}
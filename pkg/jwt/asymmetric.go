@@ -0,0 +1,141 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// es256SignatureLength подпись ES256 в JWS хранится как r||s по 32 байта, а не в DER
+const es256SignatureLength = 64
+
+// Sign Формирование токена, подписанного RS256 или ES256. Полезная нагрузка может содержать произвольные поля
+func Sign(algorithm, keyId string, payload any, key crypto.Signer) (string, error) {
+	header, err := json.Marshal(Header{Algorithm: algorithm, Type: "JWT", KeyId: keyId})
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(header) + "." + encodeSegment(body)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	switch algorithm {
+	case RS256:
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return "", ErrUnsupportedAlg
+		}
+		signature, err = rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+	case ES256:
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return "", ErrUnsupportedAlg
+		}
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		if err == nil {
+			signature = make([]byte, es256SignatureLength)
+			r.FillBytes(signature[:es256SignatureLength/2])
+			s.FillBytes(signature[es256SignatureLength/2:])
+		}
+	default:
+		return "", ErrUnsupportedAlg
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + encodeSegment(signature), nil
+}
+
+// ParseWithKeySet Проверка подписи RS256/ES256 ключом из набора, срока действия, издателя и получателя токена.
+// Кроме стандартных полей возвращается вся полезная нагрузка для чтения дополнительных claims
+func ParseWithKeySet(token string, keySet *KeySet, issuer, audience string) (*Claims, map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, ErrMalformedToken
+	}
+
+	header := &Header{}
+	if err := decodeSegment(parts[0], header); err != nil {
+		return nil, nil, err
+	}
+
+	publicKey, ok := keySet.Get(header.KeyId)
+	if !ok {
+		return nil, nil, ErrUnknownKey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, ErrMalformedToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	switch header.Algorithm {
+	case RS256:
+		rsaKey, ok := publicKey.(*rsa.PublicKey)
+		if !ok {
+			return nil, nil, ErrUnsupportedAlg
+		}
+		if rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, digest[:], signature) != nil {
+			return nil, nil, ErrInvalidSignature
+		}
+	case ES256:
+		ecKey, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, nil, ErrUnsupportedAlg
+		}
+		if len(signature) != es256SignatureLength {
+			return nil, nil, ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:es256SignatureLength/2])
+		s := new(big.Int).SetBytes(signature[es256SignatureLength/2:])
+		if !ecdsa.Verify(ecKey, digest[:], r, s) {
+			return nil, nil, ErrInvalidSignature
+		}
+	default:
+		return nil, nil, ErrUnsupportedAlg
+	}
+
+	claims := &Claims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, nil, err
+	}
+	payload := map[string]any{}
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return nil, nil, err
+	}
+
+	// токен провайдера без срока действия не принимается
+	now := time.Now().Unix()
+	if claims.ExpiresAt == 0 {
+		return nil, nil, ErrMissingExpiry
+	}
+	if now >= claims.ExpiresAt {
+		return nil, nil, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, nil, ErrTokenNotYetValid
+	}
+	if issuer != "" && claims.Issuer != issuer {
+		return nil, nil, ErrInvalidIssuer
+	}
+	if audience != "" && !claims.Audience.Contains(audience) {
+		return nil, nil, ErrInvalidAudience
+	}
+
+	return claims, payload, nil
+}
@@ -0,0 +1,156 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var ErrUnsupportedKey = errors.New("unsupported json web key")
+
+// JWK Открытый ключ в формате JSON Web Key (RFC 7517). Поддерживаются ключи RSA и EC P-256
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyId     string `json:"kid,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// JWKS Набор ключей в том виде, в котором его публикует провайдер
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet Открытые ключи провайдера по kid
+type KeySet struct {
+	keys map[string]crypto.PublicKey
+}
+
+// ParseJWKS Разбор набора ключей. Ключи неподдерживаемых типов и ключи для шифрования пропускаются
+func ParseJWKS(data []byte) (*KeySet, error) {
+	jwks := &JWKS{}
+	if err := json.Unmarshal(data, jwks); err != nil {
+		return nil, err
+	}
+
+	keySet := &KeySet{keys: make(map[string]crypto.PublicKey, len(jwks.Keys))}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		publicKey, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keySet.keys[jwk.KeyId] = publicKey
+	}
+
+	if len(keySet.keys) == 0 {
+		return nil, errors.New("json web key set does not contain signing keys")
+	}
+	return keySet, nil
+}
+
+// Get Ключ по kid. Если в наборе один ключ, то он используется и для токенов без kid
+func (k *KeySet) Get(keyId string) (crypto.PublicKey, bool) {
+	if publicKey, ok := k.keys[keyId]; ok {
+		return publicKey, true
+	}
+	if keyId == "" && len(k.keys) == 1 {
+		for _, publicKey := range k.keys {
+			return publicKey, true
+		}
+	}
+	return nil, false
+}
+
+// Len Количество ключей в наборе
+func (k *KeySet) Len() int {
+	return len(k.keys)
+}
+
+// PublicKey Преобразование JWK в открытый ключ
+func (j *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch j.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, ErrUnsupportedKey
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if j.Curve != "P-256" {
+			return nil, ErrUnsupportedKey
+		}
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, fmt.Errorf("%w: point is not on curve", ErrUnsupportedKey)
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+// NewJWK Формирование JWK для открытого ключа, например для публикации тестового набора ключей
+func NewJWK(keyId string, publicKey crypto.PublicKey) (*JWK, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return &JWK{
+			KeyType:   "RSA",
+			KeyId:     keyId,
+			Use:       "sig",
+			Algorithm: RS256,
+			N:         encodeSegment(key.N.Bytes()),
+			E:         encodeSegment(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return nil, ErrUnsupportedKey
+		}
+		return &JWK{
+			KeyType:   "EC",
+			KeyId:     keyId,
+			Use:       "sig",
+			Algorithm: ES256,
+			Curve:     "P-256",
+			X:         encodeSegment(key.X.FillBytes(make([]byte, 32))),
+			Y:         encodeSegment(key.Y.FillBytes(make([]byte, 32))),
+		}, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
+
+func decodeBigInt(segment string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil || len(data) == 0 {
+		return nil, ErrUnsupportedKey
+	}
+	return new(big.Int).SetBytes(data), nil
+}
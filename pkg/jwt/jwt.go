@@ -0,0 +1,162 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	ES256 = "ES256"
+)
+
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported token algorithm")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrMissingExpiry    = errors.New("token does not contain expiration time")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("invalid token audience")
+	ErrTokenNotYetValid = errors.New("token is not yet valid")
+	ErrUnknownKey       = errors.New("unknown token signing key")
+)
+
+// Header заголовок JWT
+type Header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyId     string `json:"kid,omitempty"`
+}
+
+// Claims стандартные поля полезной нагрузки JWT
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	Id        string   `json:"jti,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+}
+
+// Audience Поле aud, которое может быть строкой или массивом строк
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+// Contains Проверка, что токен выдан для указанного получателя
+func (a Audience) Contains(audience string) bool {
+	for _, aud := range a {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// SignHS256 Формирование токена, подписанного HMAC-SHA256
+func SignHS256(claims *Claims, key []byte) (string, error) {
+	header, err := json.Marshal(Header{Algorithm: HS256, Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(header) + "." + encodeSegment(payload)
+	return signingInput + "." + encodeSegment(signHS256(signingInput, key)), nil
+}
+
+// ParseHS256 Проверка подписи и срока действия токена, подписанного HMAC-SHA256
+func ParseHS256(token string, key []byte, issuer string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	header := &Header{}
+	if err := decodeSegment(parts[0], header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != HS256 {
+		return nil, ErrUnsupportedAlg
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	if !hmac.Equal(signature, signHS256(parts[0]+"."+parts[1], key)) {
+		return nil, ErrInvalidSignature
+	}
+
+	claims := &Claims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt != 0 && time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	if issuer != "" && claims.Issuer != issuer {
+		return nil, ErrInvalidIssuer
+	}
+
+	return claims, nil
+}
+
+// ParseHeader Чтение заголовка токена без проверки подписи, например для выбора способа проверки по алгоритму
+func ParseHeader(token string) (*Header, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	header := &Header{}
+	if err := decodeSegment(parts[0], header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func signHS256(signingInput string, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformedToken
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return ErrMalformedToken
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package paginator
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("cursor is invalid")
+
+// Cursor Позиция записи в выборке: значения полей сортировки записи и сама сортировка,
+// для которой курсор был выдан
+type Cursor struct {
+	Sort   string
+	Values []any
+}
+
+type cursorValue struct {
+	Type  string          `json:"t"`
+	Value json.RawMessage `json:"v,omitempty"`
+}
+
+type cursorToken struct {
+	Sort   string        `json:"s"`
+	Values []cursorValue `json:"v"`
+}
+
+// EncodeCursor Кодирование курсора в непрозрачную строку для передачи клиенту
+func EncodeCursor(cursor *Cursor) (string, error) {
+	token := cursorToken{Sort: cursor.Sort, Values: make([]cursorValue, 0, len(cursor.Values))}
+	for _, value := range cursor.Values {
+		encoded, err := encodeCursorValue(value)
+		if err != nil {
+			return "", err
+		}
+		token.Values = append(token.Values, *encoded)
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor Разбор курсора, выданного EncodeCursor
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	token := cursorToken{}
+	if err = json.Unmarshal(data, &token); err != nil || token.Sort == "" || len(token.Values) == 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{Sort: token.Sort, Values: make([]any, 0, len(token.Values))}
+	for _, value := range token.Values {
+		decoded, err := decodeCursorValue(value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		cursor.Values = append(cursor.Values, decoded)
+	}
+	return cursor, nil
+}
+
+// encodeCursorValue Значение сохраняется вместе с типом, чтобы при разборе сравнение в запросе шло с тем же типом
+func encodeCursorValue(value any) (*cursorValue, error) {
+	if value == nil {
+		return &cursorValue{Type: "null"}, nil
+	}
+
+	var typeName string
+	switch v := value.(type) {
+	case time.Time:
+		typeName = "time"
+		value = v.Format(time.RFC3339Nano)
+	case string:
+		typeName = "string"
+	case bool:
+		typeName = "bool"
+	default:
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			typeName = "int"
+		case reflect.Float32, reflect.Float64:
+			typeName = "float"
+			value = reflect.ValueOf(value).Float()
+		default:
+			return nil, fmt.Errorf("cursor does not support values of type %T", value)
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return &cursorValue{Type: typeName, Value: data}, nil
+}
+
+func decodeCursorValue(value cursorValue) (any, error) {
+	switch value.Type {
+	case "null":
+		return nil, nil
+	case "time":
+		var s string
+		if err := json.Unmarshal(value.Value, &s); err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case "string":
+		var s string
+		err := json.Unmarshal(value.Value, &s)
+		return s, err
+	case "bool":
+		var b bool
+		err := json.Unmarshal(value.Value, &b)
+		return b, err
+	case "int":
+		var i int64
+		err := json.Unmarshal(value.Value, &i)
+		return i, err
+	case "float":
+		var f float64
+		err := json.Unmarshal(value.Value, &f)
+		return f, err
+	}
+	return nil, ErrInvalidCursor
+}
@@ -0,0 +1,235 @@
+package paginator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type PageInterface interface {
+	PaginationInterface
+	SortInterface
+}
+
+type sortKey struct {
+	column string
+	desc   bool
+}
+
+// Find Получение страницы записей с учётом фильтров, уже применённых к db.
+// При заданном курсоре страница выбирается по значениям полей сортировки (keyset), иначе через OFFSET/LIMIT.
+// Для соседних страниц возвращаются курсоры, при WithTotal - общее количество записей
+func Find[T any](db *gorm.DB, q PageInterface, dest *[]T) (*Page, error) {
+	pagination := q.GetPagination()
+	orders := q.GetSort()
+	if len(orders) == 0 {
+		orders = []string{"id"}
+	}
+	keys := parseSortKeys(orders)
+	sort := strings.Join(orders, ",")
+	size := pageSize(pagination)
+
+	db = db.Session(&gorm.Session{})
+	page := &Page{}
+
+	if pagination.WithTotal {
+		var total int64
+		if err := db.Model(new(T)).Count(&total).Error; err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	cursor := pagination.After
+	reversed := false
+	if pagination.Before != nil {
+		cursor = pagination.Before
+		reversed = true
+	}
+
+	query := db
+	if cursor != nil {
+		if cursor.Sort != sort || len(cursor.Values) != len(keys) {
+			return nil, ErrInvalidCursor
+		}
+		condition, args := keysetCondition(keys, cursor.Values, reversed)
+		query = query.Where(condition, args...)
+	} else if pagination.From > 0 {
+		query = query.Offset(pagination.From)
+	}
+
+	for _, key := range keys {
+		if key.desc != reversed {
+			query = query.Order(key.column + " DESC")
+		} else {
+			query = query.Order(key.column)
+		}
+	}
+
+	// лишняя запись показывает, есть ли следующая страница
+	err := query.Limit(size + 1).Find(dest).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(*dest) > size
+	if hasMore {
+		*dest = (*dest)[:size]
+	}
+	if reversed {
+		for i, j := 0, len(*dest)-1; i < j; i, j = i+1, j-1 {
+			(*dest)[i], (*dest)[j] = (*dest)[j], (*dest)[i]
+		}
+	}
+	if len(*dest) == 0 {
+		return page, nil
+	}
+
+	first, err := rowCursor(db, &(*dest)[0], keys, sort)
+	if err != nil {
+		return nil, err
+	}
+	last, err := rowCursor(db, &(*dest)[len(*dest)-1], keys, sort)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case pagination.Before != nil:
+		page.Next = last
+		if hasMore {
+			page.Prev = first
+		}
+	case pagination.After != nil:
+		page.Prev = first
+		if hasMore {
+			page.Next = last
+		}
+	default:
+		if hasMore {
+			page.Next = last
+		}
+		if pagination.From > 0 {
+			page.Prev = first
+		}
+	}
+
+	return page, nil
+}
+
+// parseSortKeys Разбор сортировки вида "weight DESC"
+func parseSortKeys(orders []string) []sortKey {
+	keys := make([]sortKey, 0, len(orders))
+	for _, order := range orders {
+		fields := strings.Fields(order)
+		keys = append(keys, sortKey{
+			column: fields[0],
+			desc:   len(fields) > 1 && strings.EqualFold(fields[1], "DESC"),
+		})
+	}
+	return keys
+}
+
+// keysetCondition Условие выбора записей, следующих за курсором в порядке сортировки keys.
+// Учитывается порядок NULL в PostgreSQL: в конце при сортировке по возрастанию и в начале при сортировке по убыванию
+func keysetCondition(keys []sortKey, values []any, reversed bool) (string, []any) {
+	alternatives := make([]string, 0, len(keys))
+	args := make([]any, 0)
+
+	for i, key := range keys {
+		parts := make([]string, 0, i+1)
+		partArgs := make([]any, 0, i+1)
+
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				parts = append(parts, keys[j].column+" IS NULL")
+			} else {
+				parts = append(parts, keys[j].column+" = ?")
+				partArgs = append(partArgs, values[j])
+			}
+		}
+
+		desc := key.desc != reversed
+		switch {
+		case values[i] == nil && desc:
+			parts = append(parts, key.column+" IS NOT NULL")
+		case values[i] == nil:
+			// после NULL при сортировке по возрастанию ничего нет
+			continue
+		case desc:
+			parts = append(parts, key.column+" < ?")
+			partArgs = append(partArgs, values[i])
+		default:
+			parts = append(parts, fmt.Sprintf("(%s > ? OR %s IS NULL)", key.column, key.column))
+			partArgs = append(partArgs, values[i])
+		}
+
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+		args = append(args, partArgs...)
+	}
+
+	if len(alternatives) == 0 {
+		return "1 = 0", nil
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// rowCursor Курсор, указывающий на запись row
+func rowCursor(db *gorm.DB, row any, keys []sortKey, sort string) (*Cursor, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(row); err != nil {
+		return nil, err
+	}
+
+	cursor := &Cursor{Sort: sort, Values: make([]any, 0, len(keys))}
+	rowValue := reflect.ValueOf(row).Elem()
+	for _, key := range keys {
+		field := stmt.Schema.LookUpField(key.column)
+		if field == nil {
+			return nil, fmt.Errorf("cursor field %s is not found in %s", key.column, stmt.Schema.Name)
+		}
+
+		value, _ := field.ValueOf(context.Background(), rowValue)
+		reflectValue := reflect.ValueOf(value)
+		if reflectValue.Kind() == reflect.Ptr {
+			if reflectValue.IsNil() {
+				value = nil
+			} else {
+				value = reflectValue.Elem().Interface()
+			}
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	return cursor, nil
+}
+
+// FindInBatches Обход всех записей выборки страницами по size записей, как при переходе по курсору Next.
+// В памяти одновременно находится только одна страница. From, Before и WithTotal из q не учитываются
+func FindInBatches[T any](db *gorm.DB, q PageInterface, size int, fn func(batch []T) error) error {
+	pagination := q.GetPagination()
+	pagination.From = 0
+	pagination.Size = size
+	pagination.After = nil
+	pagination.Before = nil
+	pagination.WithTotal = false
+
+	for {
+		batch := make([]T, 0, pageSize(pagination))
+		page, err := Find(db, q, &batch)
+		if err != nil {
+			return err
+		}
+		if len(batch) > 0 {
+			if err = fn(batch); err != nil {
+				return err
+			}
+		}
+		if page.Next == nil {
+			return nil
+		}
+		pagination.After = page.Next
+	}
+}
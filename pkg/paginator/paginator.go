@@ -1,31 +1,30 @@
 package paginator
 
-import (
-	"gorm.io/gorm"
-)
-
 type Pagination struct {
 	From int
 	Size int
+	// After курсор записи, после которой начинается страница. Заменяет From
+	After *Cursor
+	// Before курсор записи, перед которой заканчивается страница
+	Before *Cursor
+	// WithTotal подсчёт общего количества записей, подходящих под фильтр
+	WithTotal bool
 }
 
 type PaginationInterface interface {
 	GetPagination() *Pagination
 }
 
-// Paginate реализация пагинации
-func Paginate(q PaginationInterface) func(db *gorm.DB) *gorm.DB {
-	return func(db *gorm.DB) *gorm.DB {
-		from := q.GetPagination().From
-		if from < 0 {
-			from = 0
-		}
-
-		size := q.GetPagination().Size
-		if size <= 0 {
-			size = 10
-		}
+// Page Сведения о полученной странице: курсоры соседних страниц и общее количество записей
+type Page struct {
+	Next  *Cursor
+	Prev  *Cursor
+	Total *int64
+}
 
-		return db.Offset(from).Limit(size)
+func pageSize(pagination *Pagination) int {
+	if pagination.Size <= 0 {
+		return 10
 	}
+	return pagination.Size
 }
@@ -0,0 +1,6 @@
+package paginator
+
+// SortInterface Поля сортировки фильтра. Если поля не заданы, Find сортирует записи по id
+type SortInterface interface {
+	GetSort() []string
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/service/accountcache"
+	"testing"
+	"time"
+)
+
+func TestAccountCacheInvalidate(t *testing.T) {
+	accountCache := accountcache.NewAccountCacheService(accountcache.Params{TTL: time.Minute})
+	account := &entity.Account{Id: 1, Email: "user@simbirsoft.com", Role: entity.UserRole}
+
+	accountCache.SetByCreds(account.Email, "qwerty123", account, accountCache.Generation())
+	accountCache.SetById(account, accountCache.Generation())
+
+	if _, ok := accountCache.GetByCreds(account.Email, "qwerty124"); ok {
+		t.Errorf("cache hit with wrong password")
+	}
+	if cached, ok := accountCache.GetByCreds(account.Email, "qwerty123"); !ok || cached.Id != account.Id {
+		t.Errorf("cache miss with correct password")
+	}
+
+	accountCache.Invalidate(account.Id)
+
+	if _, ok := accountCache.GetByCreds(account.Email, "qwerty123"); ok {
+		t.Errorf("cache hit after invalidation")
+	}
+	if _, ok := accountCache.GetById(account.Id); ok {
+		t.Errorf("cache hit after invalidation")
+	}
+
+	stats := accountCache.Stats()
+	if stats.Hits != 1 || stats.Misses != 3 {
+		t.Errorf("got %d hits and %d misses, wanted 1 and 3", stats.Hits, stats.Misses)
+	}
+}
+
+func TestAccountCacheExpiration(t *testing.T) {
+	accountCache := accountcache.NewAccountCacheService(accountcache.Params{TTL: 10 * time.Millisecond})
+	account := &entity.Account{Id: 1}
+
+	accountCache.SetById(account, accountCache.Generation())
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := accountCache.GetById(account.Id); ok {
+		t.Errorf("cache hit after expiration")
+	}
+}
+
+func TestAccountCacheDropsStaleSet(t *testing.T) {
+	accountCache := accountcache.NewAccountCacheService(accountcache.Params{TTL: time.Minute})
+	account := &entity.Account{Id: 1, Email: "user@simbirsoft.com", Role: entity.UserRole}
+	otherAccount := &entity.Account{Id: 2, Email: "other@simbirsoft.com", Role: entity.UserRole}
+
+	// аккаунт прочитан до смены пароля, а сохраняется в кеш после инвалидации
+	generation := accountCache.Generation()
+	accountCache.Invalidate(account.Id)
+	accountCache.SetByCreds(account.Email, "qwerty123", account, generation)
+	accountCache.SetById(account, generation)
+	accountCache.SetById(otherAccount, generation)
+
+	if _, ok := accountCache.GetByCreds(account.Email, "qwerty123"); ok {
+		t.Errorf("stale credentials cached after invalidation")
+	}
+	if _, ok := accountCache.GetById(account.Id); ok {
+		t.Errorf("stale account cached after invalidation")
+	}
+	if _, ok := accountCache.GetById(otherAccount.Id); !ok {
+		t.Errorf("account without invalidation was not cached")
+	}
+
+	accountCache.SetById(account, accountCache.Generation())
+	if _, ok := accountCache.GetById(account.Id); !ok {
+		t.Errorf("account read after invalidation was not cached")
+	}
+}
@@ -0,0 +1,43 @@
+package test
+
+import (
+	"it-planet-task/internal/app/filter"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestAccountFilterSort(t *testing.T) {
+	params, httpErr := filter.NewAccountFilterParams(url.Values{"sort": {"lastName,-id"}, "role": {"chipper"}, "status": {"disabled"}})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if want := []string{"last_name", "id DESC"}; !reflect.DeepEqual(params.Sort, want) {
+		t.Errorf("got sort %v, wanted %v", params.Sort, want)
+	}
+	if params.Role != "CHIPPER" || params.Status != "DISABLED" {
+		t.Errorf("got role %q and status %q", params.Role, params.Status)
+	}
+
+	params, httpErr = filter.NewAccountFilterParams(url.Values{"sort": {"-email"}})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if want := []string{"email DESC", "id"}; !reflect.DeepEqual(params.Sort, want) {
+		t.Errorf("got sort %v, wanted %v", params.Sort, want)
+	}
+}
+
+func TestAccountFilterRejectsInvalidParams(t *testing.T) {
+	cases := []url.Values{
+		{"sort": {"password"}},
+		{"sort": {"id,-id"}},
+		{"status": {"DELETED"}},
+	}
+
+	for _, q := range cases {
+		if _, httpErr := filter.NewAccountFilterParams(q); httpErr == nil {
+			t.Errorf("%v: expected error", q)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package test
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"testing"
+)
+
+func TestAccountIsActive(t *testing.T) {
+	cases := []struct {
+		status string
+		want   bool
+	}{
+		{entity.ActiveStatus, true},
+		{entity.DisabledStatus, false},
+		{"", true},
+	}
+
+	for _, tc := range cases {
+		account := &entity.Account{Status: tc.status}
+		if got := account.IsActive(); got != tc.want {
+			t.Errorf("status %q: got %v, wanted %v", tc.status, got, tc.want)
+		}
+	}
+}
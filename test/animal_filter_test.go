@@ -0,0 +1,75 @@
+package test
+
+import (
+	"it-planet-task/internal/app/filter"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestAnimalFilterExtendedParams(t *testing.T) {
+	params, httpErr := filter.NewAnimalFilterParams(url.Values{
+		"animalTypeIds":     {"1,2", "2", "3"},
+		"animalTypesMatch":  {"ALL"},
+		"minWeight":         {"1.5"},
+		"maxWeight":         {"10"},
+		"visitedLocationId": {"7"},
+		"areaId":            {"4"},
+	})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(params.AnimalTypeIds, want) {
+		t.Errorf("got animal types %v, wanted %v", params.AnimalTypeIds, want)
+	}
+	if params.AnimalTypesMatch != filter.AllAnimalTypesMatch {
+		t.Errorf("got animal types match %q", params.AnimalTypesMatch)
+	}
+	if *params.MinWeight != 1.5 || *params.MaxWeight != 10 || params.MinHeight != nil {
+		t.Errorf("got weight range %v..%v", *params.MinWeight, *params.MaxWeight)
+	}
+	if params.VisitedLocationId != 7 || params.AreaId != 4 {
+		t.Errorf("got visited location %d and area %d", params.VisitedLocationId, params.AreaId)
+	}
+}
+
+func TestAnimalFilterRejectsInvalidParams(t *testing.T) {
+	cases := []url.Values{
+		{"animalTypeIds": {"1,x"}},
+		{"animalTypeIds": {"0"}},
+		{"animalTypesMatch": {"some"}},
+		{"minHeight": {"-1"}},
+		{"minLength": {"5"}, "maxLength": {"2"}},
+		{"lastSeenAfter": {"yesterday"}},
+		{"areaId": {"-3"}},
+		{"sort": {"chipper"}},
+	}
+
+	for _, q := range cases {
+		if _, httpErr := filter.NewAnimalFilterParams(q); httpErr == nil {
+			t.Errorf("%v: expected error", q)
+		}
+	}
+}
+
+func TestSearchFiltersSort(t *testing.T) {
+	animalParams, httpErr := filter.NewAnimalFilterParams(url.Values{"sort": {"-weight,chippingDateTime"}})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if want := []string{"weight DESC", "chipping_date_time", "id"}; !reflect.DeepEqual(animalParams.GetSort(), want) {
+		t.Errorf("got animal sort %v, wanted %v", animalParams.GetSort(), want)
+	}
+
+	areaParams, httpErr := filter.NewAreaFilterParams(url.Values{})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if want := []string{"id"}; !reflect.DeepEqual(areaParams.GetSort(), want) {
+		t.Errorf("got area sort %v, wanted %v", areaParams.GetSort(), want)
+	}
+
+	if _, httpErr = filter.NewAnimalLocationFilterParams(url.Values{"sort": {"animalId"}}); httpErr == nil {
+		t.Error("expected error for sorting visited locations by animalId")
+	}
+}
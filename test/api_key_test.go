@@ -0,0 +1,51 @@
+package test
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service"
+	"testing"
+	"time"
+)
+
+// fakeApiKeyRepository Ключ в памяти с подсчётом обновлений времени последнего использования
+type fakeApiKeyRepository struct {
+	repository.ApiKey
+	apiKey  entity.ApiKey
+	updates int
+}
+
+func (f *fakeApiKeyRepository) GetByHash(hash string) (*entity.ApiKey, error) {
+	apiKey := f.apiKey
+	return &apiKey, nil
+}
+
+func (f *fakeApiKeyRepository) UpdateLastUsed(id int, lastUsedAt time.Time) error {
+	f.apiKey.LastUsedAt = &lastUsedAt
+	f.updates++
+	return nil
+}
+
+func TestApiKeyAuthenticateUpdatesLastUsedOncePerMinute(t *testing.T) {
+	apiKeyRepo := &fakeApiKeyRepository{apiKey: entity.ApiKey{Id: 1}}
+	apiKeyService := service.NewApiKeyService(apiKeyRepo)
+
+	for i := 0; i < 3; i++ {
+		if _, err := apiKeyService.Authenticate("ipk_key"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if apiKeyRepo.updates != 1 {
+		t.Errorf("got %d last used updates, wanted 1", apiKeyRepo.updates)
+	}
+
+	stale := time.Now().Add(-2 * time.Minute)
+	apiKeyRepo.apiKey.LastUsedAt = &stale
+	apiKey, err := apiKeyService.Authenticate("ipk_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apiKeyRepo.updates != 2 || !apiKey.LastUsedAt.After(stale) {
+		t.Errorf("stale last used time was not updated")
+	}
+}
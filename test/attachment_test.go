@@ -0,0 +1,102 @@
+package test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/service/blobstore"
+	"it-planet-task/internal/app/service/thumbnail"
+	"it-planet-task/internal/app/validator/AttachmentValidator"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	store := blobstore.NewLocalBlobStore(t.TempDir())
+
+	err := store.Put("animals/1/photo", strings.NewReader("content"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := store.Get("animals/1/photo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(content)
+	content.Close()
+	if string(data) != "content" {
+		t.Errorf("unexpected content %q", data)
+	}
+
+	if err = store.Delete("animals/1/photo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = store.Get("animals/1/photo"); err != blobstore.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	for _, key := range []string{"", "../outside", "/etc/passwd", "animals/../../outside"} {
+		if err = store.Put(key, strings.NewReader("x")); err != blobstore.ErrInvalidKey {
+			t.Errorf("%q: expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	for x := 0; x < 800; x++ {
+		for y := 0; y < 400; y++ {
+			src.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := thumbnail.Generate(bytes.NewReader(buf.Bytes()), 256, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	thumb, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thumb.Bounds().Dx() != 256 || thumb.Bounds().Dy() != 128 {
+		t.Errorf("unexpected thumbnail size %v", thumb.Bounds())
+	}
+
+	if _, err = thumbnail.Generate(bytes.NewReader(buf.Bytes()), 256, 800*400-1); err != thumbnail.ErrImageTooLarge {
+		t.Errorf("expected ErrImageTooLarge, got %v", err)
+	}
+	if _, err = thumbnail.Generate(strings.NewReader("%PDF-1.4"), 256, 0); err != thumbnail.ErrUnsupportedImage {
+		t.Errorf("expected ErrUnsupportedImage, got %v", err)
+	}
+}
+
+func TestValidateAttachment(t *testing.T) {
+	valid := &entity.Attachment{FileName: "photo.png", Size: 100, ContentType: "image/png"}
+	if httpErr := AttachmentValidator.ValidateAttachment(valid, 1000); httpErr != nil {
+		t.Fatal(httpErr)
+	}
+
+	cases := map[string]struct {
+		attachment *entity.Attachment
+		statusCode int
+	}{
+		"empty":      {&entity.Attachment{FileName: "a.png", ContentType: "image/png"}, http.StatusBadRequest},
+		"too large":  {&entity.Attachment{FileName: "a.png", Size: 1001, ContentType: "image/png"}, http.StatusRequestEntityTooLarge},
+		"executable": {&entity.Attachment{FileName: "a.exe", Size: 10, ContentType: "application/octet-stream"}, http.StatusUnsupportedMediaType},
+	}
+	for name, c := range cases {
+		httpErr := AttachmentValidator.ValidateAttachment(c.attachment, 1000)
+		if httpErr == nil || httpErr.StatusCode != c.statusCode {
+			t.Errorf("%s: expected status %d, got %v", name, c.statusCode, httpErr)
+		}
+	}
+}
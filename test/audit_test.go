@@ -0,0 +1,66 @@
+package test
+
+import (
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/service/audit"
+	"it-planet-task/pkg/paginator"
+	"testing"
+	"time"
+)
+
+type securityEventRepoStub struct {
+	created []entity.SecurityEvent
+}
+
+func (s *securityEventRepoStub) Create(securityEvent *entity.SecurityEvent) error {
+	s.created = append(s.created, *securityEvent)
+	return nil
+}
+
+func (s *securityEventRepoStub) Search(params *filter.SecurityEventFilterParams) (*[]entity.SecurityEvent, *paginator.Page, error) {
+	return &s.created, &paginator.Page{}, nil
+}
+
+func (s *securityEventRepoStub) DeleteBefore(before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestAuditSkipsRepeatedSuccesses(t *testing.T) {
+	repo := &securityEventRepoStub{}
+	auditService := audit.NewAuditService(repo, audit.Params{SuccessInterval: time.Minute})
+	accountId := 1
+
+	success := func(ip string) *entity.SecurityEvent {
+		return &entity.SecurityEvent{AccountId: &accountId, Type: entity.AuthSuccessEvent, Method: entity.BasicAuthMethod, Ip: ip}
+	}
+
+	auditService.Record(success("10.0.0.1"))
+	auditService.Record(success("10.0.0.1"))
+	auditService.Record(success("10.0.0.2"))
+	auditService.Record(&entity.SecurityEvent{AccountId: &accountId, Type: entity.AuthFailureEvent, Method: entity.BasicAuthMethod, Ip: "10.0.0.1"})
+	auditService.Record(&entity.SecurityEvent{AccountId: &accountId, Type: entity.AuthFailureEvent, Method: entity.BasicAuthMethod, Ip: "10.0.0.1"})
+
+	if got := len(repo.created); got != 3 {
+		t.Errorf("got %d events, wanted %d", got, 3)
+	}
+}
+
+func TestAuditSkipsRepeatedFailures(t *testing.T) {
+	repo := &securityEventRepoStub{}
+	auditService := audit.NewAuditService(repo, audit.Params{FailureInterval: time.Minute})
+
+	failure := func(ip, reason string) *entity.SecurityEvent {
+		return &entity.SecurityEvent{Email: "user@simbirsoft.com", Type: entity.AuthFailureEvent, Method: entity.LoginAuthMethod, Ip: ip, Reason: reason}
+	}
+
+	for i := 0; i < 10; i++ {
+		auditService.Record(failure("10.0.0.1", "too many failed attempts"))
+	}
+	auditService.Record(failure("10.0.0.1", "Invalid email or password"))
+	auditService.Record(failure("10.0.0.2", "too many failed attempts"))
+
+	if got := len(repo.created); got != 3 {
+		t.Errorf("got %d events, wanted %d", got, 3)
+	}
+}
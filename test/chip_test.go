@@ -0,0 +1,38 @@
+package test
+
+import (
+	"it-planet-task/internal/app/service/chip"
+	"testing"
+)
+
+func TestParseChipCode(t *testing.T) {
+	code, err := chip.Parse("643 094100012345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.Value != "643094100012345" || code.CountryCode == nil || *code.CountryCode != 643 || code.CountryName() != "Russia" {
+		t.Errorf("unexpected country decoding %+v", code)
+	}
+	if code.NationalId != 94100012345 {
+		t.Errorf("unexpected national id %d", code.NationalId)
+	}
+
+	code, err = chip.Parse("985-120031234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.CountryCode != nil || code.ManufacturerCode == nil || code.ManufacturerName() != "Destron Fearing" {
+		t.Errorf("unexpected manufacturer decoding %+v", code)
+	}
+
+	code, err = chip.Parse("999000000000001")
+	if err != nil || !code.Test {
+		t.Errorf("expected test transponder, got %+v %v", code, err)
+	}
+
+	for _, invalid := range []string{"", "64309410001234", "6430941000123456", "64309410001234a", "000094100012345", "643999999999999"} {
+		if _, err = chip.Parse(invalid); err == nil {
+			t.Errorf("%q: expected error", invalid)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"it-planet-task/internal/app/validator"
+	"it-planet-task/pkg/paginator"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	visited := time.Date(2023, 4, 1, 10, 30, 0, 123456000, time.UTC)
+	cursor := &paginator.Cursor{
+		Sort:   "weight DESC,death_date_time,chipping_date_time,gender,id",
+		Values: []any{float32(2.5), nil, visited, "MALE", 42},
+	}
+
+	token, err := paginator.EncodeCursor(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := paginator.DecodeCursor(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []any{float64(2.5), nil, visited, "MALE", int64(42)}
+	if decoded.Sort != cursor.Sort || !reflect.DeepEqual(decoded.Values, want) {
+		t.Errorf("got cursor %v, wanted %v", decoded, want)
+	}
+
+	if _, err = paginator.DecodeCursor("not-a-cursor"); err == nil {
+		t.Error("expected error for invalid cursor")
+	}
+}
+
+func TestValidatePage(t *testing.T) {
+	token, _ := paginator.EncodeCursor(&paginator.Cursor{Sort: "id", Values: []any{10}})
+
+	pagination, httpErr := validator.ValidateAndReturnPage(url.Values{"after": {token}, "size": {"5"}, "total": {"true"}})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if pagination.After == nil || pagination.Size != 5 || !pagination.WithTotal {
+		t.Errorf("got pagination %+v", pagination)
+	}
+
+	cases := []url.Values{
+		{"after": {token}, "before": {token}},
+		{"after": {token}, "from": {"10"}},
+		{"before": {"???"}},
+		{"total": {"maybe"}},
+	}
+	for _, q := range cases {
+		if _, httpErr := validator.ValidateAndReturnPage(q); httpErr == nil {
+			t.Errorf("%v: expected error", q)
+		}
+	}
+}
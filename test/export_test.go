@@ -0,0 +1,99 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"it-planet-task/internal/app/service/exporter"
+	"strings"
+	"testing"
+	"time"
+)
+
+var exportColumns = []string{"id", "animalTypes", "weight", "deathDateTime"}
+
+func writeExport(t *testing.T, format string) string {
+	var buf bytes.Buffer
+	writer, err := exporter.NewWriter(format, &buf, exportColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chipped := time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)
+	if err = writer.Write([]any{1, []int{1, 2}, float32(10.5), &chipped}, exporter.Point(55.75, 37.61)); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("%s: expected buffered output before flush", format)
+	}
+	var noDeath *time.Time
+	if err = writer.Write([]any{2, []int{3}, float32(1), noDeath}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestExportCSV(t *testing.T) {
+	expected := "id,animalTypes,weight,deathDateTime,geometry\n" +
+		"1,1;2,10.5,2023-01-02T10:00:00Z,POINT (37.61 55.75)\n" +
+		"2,3,1,,\n"
+	if got := writeExport(t, exporter.CSV); got != expected {
+		t.Errorf("unexpected csv:\n%s", got)
+	}
+}
+
+func TestExportNDJSON(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(writeExport(t, exporter.NDJSON)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], `{"id":1,"animalTypes":[1,2],"weight":10.5,`) {
+		t.Errorf("expected columns in order, got %s", lines[0])
+	}
+	if lines[1] != `{"id":2,"animalTypes":[3],"weight":1,"deathDateTime":null,"geometry":null}` {
+		t.Errorf("unexpected second line %s", lines[1])
+	}
+}
+
+func TestExportGeoJSON(t *testing.T) {
+	collection := struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Type     string `json:"type"`
+			Geometry *struct {
+				Type        string    `json:"type"`
+				Coordinates []float64 `json:"coordinates"`
+			} `json:"geometry"`
+			Properties map[string]any `json:"properties"`
+		} `json:"features"`
+	}{}
+	if err := json.Unmarshal([]byte(writeExport(t, exporter.GeoJSON)), &collection); err != nil {
+		t.Fatal(err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 2 {
+		t.Fatalf("unexpected collection %+v", collection)
+	}
+	point := collection.Features[0].Geometry
+	if point == nil || point.Type != "Point" || point.Coordinates[0] != 37.61 || point.Coordinates[1] != 55.75 {
+		t.Errorf("expected point in longitude, latitude order, got %+v", point)
+	}
+	if collection.Features[1].Geometry != nil || collection.Features[1].Properties["id"] != float64(2) {
+		t.Errorf("unexpected second feature %+v", collection.Features[1])
+	}
+
+	var buf bytes.Buffer
+	writer, _ := exporter.NewWriter(exporter.GeoJSON, &buf, exportColumns)
+	_ = writer.Close()
+	if buf.String() != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("unexpected empty collection %s", buf.String())
+	}
+}
+
+func TestExportPolygonWKT(t *testing.T) {
+	polygon := exporter.Polygon([][2]float64{{0, 0}, {0, 10}, {10, 10}})
+	if wkt := polygon.WKT(); wkt != "POLYGON ((0 0, 10 0, 10 10, 0 0))" {
+		t.Errorf("unexpected wkt %s", wkt)
+	}
+}
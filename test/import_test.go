@@ -0,0 +1,100 @@
+package test
+
+import (
+	"errors"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/importer"
+	"it-planet-task/internal/app/validator/AnimalValidator"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	file := "animalTypes,weight,height,length,gender,chipperId,chippingLocationId,chippingDateTime,chipCode\n" +
+		"1;2,10.5,1,2,MALE,1,3,2023-01-02T10:00:00Z,643 094100012345\n" +
+		"1,heavy,1,2,MALE,1,3,,\n" +
+		"1,10,1,2,FEMALE,1,3\n" +
+		"a\"b,1,1,2,MALE,1,3,,\n" +
+		"1,10,1,2,FEMALE,1,3,,\n"
+
+	rows, err := importer.Parse(importer.CSV, strings.NewReader(file), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(rows))
+	}
+
+	animal := rows[0].Animal
+	if rows[0].Err != nil || rows[0].Line != 2 {
+		t.Fatalf("unexpected first row %+v", rows[0])
+	}
+	if len(animal.AnimalTypeIds) != 2 || *animal.Weight != 10.5 || *animal.ChipperId != 1 || *animal.ChipCode != "643 094100012345" {
+		t.Errorf("unexpected animal %+v", animal)
+	}
+	if !animal.ChippingDateTime.Equal(time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected chippingDateTime %v", animal.ChippingDateTime)
+	}
+	if rows[1].Err == nil || !strings.Contains(rows[1].Err.Error(), "weight") || rows[1].Line != 3 {
+		t.Errorf("expected weight error on line 3, got %+v", rows[1])
+	}
+	if rows[2].Err == nil || rows[2].Line != 4 {
+		t.Errorf("expected field count error on line 4, got %+v", rows[2])
+	}
+	if rows[3].Err == nil || rows[3].Line != 5 {
+		t.Errorf("expected bare quote error on line 5, got %+v", rows[3])
+	}
+	if rows[4].Err != nil || rows[4].Line != 6 {
+		t.Errorf("expected valid row on line 6 after malformed quote, got %+v", rows[4])
+	}
+
+	if _, err = importer.Parse(importer.CSV, strings.NewReader("animalTypes,color\n"), 10); err == nil {
+		t.Error("expected unknown column error")
+	}
+	if _, err = importer.Parse(importer.CSV, strings.NewReader(file), 4); !errors.Is(err, importer.ErrTooManyRows) {
+		t.Errorf("expected too many rows, got %v", err)
+	}
+}
+
+func TestParseImportNDJSON(t *testing.T) {
+	file := `{"animalTypes":[1],"weight":1,"height":1,"length":1,"gender":"MALE","chipperId":1,"chippingLocationId":1}` + "\n\n" +
+		`{"animalTypes":[1],"color":"red"}` + "\n"
+
+	rows, err := importer.Parse(importer.NDJSON, strings.NewReader(file), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].Err != nil || rows[1].Err == nil || rows[1].Line != 3 {
+		t.Fatalf("unexpected rows %+v", rows)
+	}
+
+	httpErr := AnimalValidator.ValidateAnimalImportInput(rows[0].Animal)
+	if httpErr != nil {
+		t.Errorf("unexpected validation error %v", httpErr.Err)
+	}
+	future := time.Now().Add(time.Hour)
+	rows[0].Animal.ChippingDateTime = &future
+	if AnimalValidator.ValidateAnimalImportInput(rows[0].Animal) == nil {
+		t.Error("expected chippingDateTime in the future to be rejected")
+	}
+}
+
+func TestImportFormatFromContentType(t *testing.T) {
+	if importer.FormatFromContentType("text/csv; charset=utf-8") != importer.CSV {
+		t.Error("expected csv")
+	}
+	if importer.FormatFromContentType("application/x-ndjson") != importer.NDJSON {
+		t.Error("expected ndjson")
+	}
+	if importer.FormatFromContentType("application/json") != "" {
+		t.Error("expected unknown format")
+	}
+}
+
+func TestAnimalImportDefaultLimits(t *testing.T) {
+	importService := service.NewAnimalImportService(nil, nil, nil, nil, nil, service.AnimalImportParams{})
+	if importService.MaxSize() != service.DefaultImportMaxSize {
+		t.Errorf("got max size %d, wanted %d", importService.MaxSize(), service.DefaultImportMaxSize)
+	}
+}
@@ -0,0 +1,60 @@
+package test
+
+import (
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/validator/AnimalValidator"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestValidateParent(t *testing.T) {
+	chipping := time.Now()
+	birth := chipping.Add(-24 * time.Hour)
+	parentBirth := birth.Add(-365 * 24 * time.Hour)
+	deathBeforeBirth := birth.Add(-time.Hour)
+
+	mother := &response.Animal{Id: 2, Gender: entity.Female, BirthDateTime: &parentBirth}
+	if httpErr := AnimalValidator.ValidateParent(mother, entity.Female, 1, &birth, chipping); httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if httpErr := AnimalValidator.ValidateParent(mother, entity.Female, 1, nil, chipping); httpErr != nil {
+		t.Fatal(httpErr)
+	}
+
+	cases := map[string]struct {
+		parent *response.Animal
+		gender string
+	}{
+		"wrong gender":  {&response.Animal{Id: 2, Gender: entity.Male}, entity.Female},
+		"itself":        {&response.Animal{Id: 1, Gender: entity.Male}, entity.Male},
+		"born after":    {&response.Animal{Id: 2, Gender: entity.Male, BirthDateTime: &birth}, entity.Male},
+		"dead at birth": {&response.Animal{Id: 2, Gender: entity.Female, DeathDateTime: &deathBeforeBirth}, entity.Female},
+	}
+	for name, c := range cases {
+		if httpErr := AnimalValidator.ValidateParent(c.parent, c.gender, 1, &birth, chipping); httpErr == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	// без даты рождения родитель должен родиться раньше чипирования
+	bornAfterChipping := &response.Animal{Id: 2, Gender: entity.Male, BirthDateTime: &chipping}
+	if httpErr := AnimalValidator.ValidateParent(bornAfterChipping, entity.Male, 1, nil, chipping); httpErr == nil {
+		t.Error("born after chipping: expected error")
+	}
+}
+
+func TestNewLineageParams(t *testing.T) {
+	params, httpErr := filter.NewLineageParams(url.Values{})
+	if httpErr != nil || params.Depth != filter.DefaultLineageDepth {
+		t.Fatalf("unexpected default params %v %v", params, httpErr)
+	}
+
+	for _, depth := range []string{"0", "11", "abc"} {
+		if _, httpErr = filter.NewLineageParams(url.Values{"depth": {depth}}); httpErr == nil {
+			t.Errorf("depth %s: expected error", depth)
+		}
+	}
+}
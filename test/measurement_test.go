@@ -0,0 +1,40 @@
+package test
+
+import (
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/model/response"
+	"it-planet-task/internal/app/validator/AnimalMeasurementValidator"
+	"testing"
+	"time"
+)
+
+func TestAnimalMeasurementInputValidation(t *testing.T) {
+	chippedAt := time.Now().Add(-30 * 24 * time.Hour)
+	animal := &response.Animal{Id: 1, LifeStatus: entity.Alive, ChippingDateTime: chippedAt}
+
+	weight, kilograms, meters := "weight", entity.KilogramUnit, entity.MeterUnit
+	value := float32(12.5)
+	measuredAt := chippedAt.Add(24 * time.Hour)
+	valid := &input.AnimalMeasurement{Type: &weight, Value: &value, Unit: &kilograms, MeasuredAt: &measuredAt}
+	if httpErr := AnimalMeasurementValidator.ValidateAnimalMeasurementInput(valid, animal); httpErr != nil {
+		t.Fatal(httpErr)
+	}
+
+	zero := float32(0)
+	beforeChipping := chippedAt.Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	unknown := "WINGSPAN"
+	cases := map[string]*input.AnimalMeasurement{
+		"unknown type":    {Type: &unknown, Value: &value},
+		"zero value":      {Type: &weight, Value: &zero},
+		"wrong unit":      {Type: &weight, Value: &value, Unit: &meters},
+		"before chipping": {Type: &weight, Value: &value, MeasuredAt: &beforeChipping},
+		"in future":       {Type: &weight, Value: &value, MeasuredAt: &future},
+	}
+	for name, measurement := range cases {
+		if httpErr := AnimalMeasurementValidator.ValidateAnimalMeasurementInput(measurement, animal); httpErr == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
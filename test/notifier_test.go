@@ -0,0 +1,55 @@
+package test
+
+import (
+	"bufio"
+	"encoding/json"
+	"it-planet-task/internal/app/service/notifier"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOutboxNotifierAppendsMessages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox", "notifications.jsonl")
+	outbox := notifier.NewOutboxNotifier(path)
+
+	for _, to := range []string{"first@mail.com", "second@mail.com"} {
+		err := outbox.Send(&notifier.Message{To: to, Subject: "Password reset", Body: "token"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var messages []notifier.Message
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var message notifier.Message
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+	}
+
+	if len(messages) != 2 {
+		t.Fatalf("got %d, wanted %d", len(messages), 2)
+	}
+	if messages[1].To != "second@mail.com" {
+		t.Errorf("got %v, wanted %v", messages[1].To, "second@mail.com")
+	}
+	if messages[0].CreatedAt.IsZero() {
+		t.Errorf("createdAt is not set")
+	}
+}
+
+func TestUnknownNotifierType(t *testing.T) {
+	_, err := notifier.NewNotifier(notifier.Params{Type: "carrier-pigeon"})
+	if err == nil {
+		t.Errorf("got nil, wanted error")
+	}
+}
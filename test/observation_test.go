@@ -0,0 +1,48 @@
+package test
+
+import (
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestObservationFilterTextSearch(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params, httpErr := filter.NewObservationFilterParams(url.Values{"q": {"хромает лапа"}})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if want := []string{"observed_at DESC", "id"}; !reflect.DeepEqual(params.GetSort(), want) {
+		t.Errorf("got sort %v, wanted %v", params.GetSort(), want)
+	}
+
+	stmt := db.Scopes(filter.ObservationFilter(params)).Find(&[]entity.Observation{}).Statement
+	sql := stmt.SQL.String()
+	if !strings.Contains(sql, "search_vector @@ (websearch_to_tsquery($1::regconfig, $2) || websearch_to_tsquery($3::regconfig, $4))") {
+		t.Errorf("unexpected sql %s", sql)
+	}
+	if want := []interface{}{entity.RussianTextSearch, "хромает лапа", entity.EnglishTextSearch, "хромает лапа"}; !reflect.DeepEqual(stmt.Vars, want) {
+		t.Errorf("got vars %v, wanted %v", stmt.Vars, want)
+	}
+
+	params, httpErr = filter.NewObservationFilterParams(url.Values{"q": {"limping"}, "language": {"en"}})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if !reflect.DeepEqual(params.TextSearchConfigs, []string{entity.EnglishTextSearch}) {
+		t.Errorf("got text search configs %v", params.TextSearchConfigs)
+	}
+
+	if _, httpErr = filter.NewObservationFilterParams(url.Values{"language": {"de"}}); httpErr == nil {
+		t.Error("expected error for unsupported language")
+	}
+}
@@ -0,0 +1,305 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"gorm.io/gorm"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/service/accountcache"
+	"it-planet-task/internal/app/service/oidc"
+	"it-planet-task/internal/app/service/password"
+	"it-planet-task/pkg/jwt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testIssuer = "https://sso.test"
+
+func writeTestJWKS(t *testing.T, keys map[string]any) string {
+	t.Helper()
+
+	jwks := jwt.JWKS{}
+	for keyId, publicKey := range keys {
+		jwk, err := jwt.NewJWK(keyId, publicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jwks.Keys = append(jwks.Keys, *jwk)
+	}
+
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func testPayload(roles ...string) map[string]any {
+	return map[string]any{
+		"iss":         testIssuer,
+		"sub":         "user-1",
+		"aud":         []string{"it-planet-task"},
+		"exp":         time.Now().Add(time.Minute).Unix(),
+		"email":       "user@example.com",
+		"given_name":  "Ivan",
+		"family_name": "Ivanov",
+		"realm_access": map[string]any{
+			"roles": roles,
+		},
+	}
+}
+
+func TestOidcAuthenticate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oidcService, err := oidc.NewOidcService(oidc.Params{
+		Enabled:    true,
+		Issuer:     testIssuer,
+		Audience:   "it-planet-task",
+		JWKSFile:   writeTestJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey}),
+		RolesClaim: "realm_access.roles",
+		RoleMapping: []oidc.RoleMapping{
+			{External: "admin", Role: "ADMIN"},
+			{External: "chipper", Role: "CHIPPER"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaToken, err := jwt.Sign(jwt.RS256, "rsa", testPayload("chipper", "admin"), rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := oidcService.Authenticate(rsaToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.ExternalId != testIssuer+"|user-1" || identity.Email != "user@example.com" || identity.FirstName != "Ivan" {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+	if identity.Role != "ADMIN" {
+		t.Errorf("got role %q, wanted %q", identity.Role, "ADMIN")
+	}
+
+	ecToken, err := jwt.Sign(jwt.ES256, "ec", testPayload("viewer"), ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err = oidcService.Authenticate(ecToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Role != "" {
+		t.Errorf("got role %q, wanted no role", identity.Role)
+	}
+}
+
+func TestOidcRejectsInvalidTokens(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oidcService, err := oidc.NewOidcService(oidc.Params{
+		Enabled:  true,
+		Issuer:   testIssuer,
+		Audience: "it-planet-task",
+		JWKSFile: writeTestJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expired := testPayload()
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	wrongAudience := testPayload()
+	wrongAudience["aud"] = "another-api"
+	withoutExpiry := testPayload()
+	delete(withoutExpiry, "exp")
+
+	cases := []struct {
+		name    string
+		keyId   string
+		key     *rsa.PrivateKey
+		payload map[string]any
+		want    error
+	}{
+		{"foreign key", "rsa", otherKey, testPayload(), jwt.ErrInvalidSignature},
+		{"unknown kid", "other", rsaKey, testPayload(), jwt.ErrUnknownKey},
+		{"expired", "rsa", rsaKey, expired, jwt.ErrTokenExpired},
+		{"without expiry", "rsa", rsaKey, withoutExpiry, jwt.ErrMissingExpiry},
+		{"wrong audience", "rsa", rsaKey, wrongAudience, jwt.ErrInvalidAudience},
+	}
+
+	for _, tc := range cases {
+		token, err := jwt.Sign(jwt.RS256, tc.keyId, tc.payload, tc.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := oidcService.Authenticate(token); err != tc.want {
+			t.Errorf("%s: got %v, wanted %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestOidcRequiresIssuer(t *testing.T) {
+	if _, err := oidc.NewOidcService(oidc.Params{Enabled: true, Audience: "it-planet-task"}); err != oidc.ErrMissingIssuer {
+		t.Errorf("got %v, wanted %v", err, oidc.ErrMissingIssuer)
+	}
+	if _, err := oidc.NewOidcService(oidc.Params{}); err != nil {
+		t.Errorf("disabled provider without issuer: %v", err)
+	}
+}
+
+func TestOidcEmailVerifiedClaim(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oidcService, err := oidc.NewOidcService(oidc.Params{
+		Enabled:  true,
+		Issuer:   testIssuer,
+		Audience: "it-planet-task",
+		JWKSFile: writeTestJWKS(t, map[string]any{"rsa": &rsaKey.PublicKey}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name  string
+		claim any
+		want  bool
+	}{
+		{"missing", nil, false},
+		{"false", false, false},
+		{"string", "true", false},
+		{"true", true, true},
+	}
+
+	for _, tc := range cases {
+		payload := testPayload()
+		if tc.claim != nil {
+			payload["email_verified"] = tc.claim
+		}
+		token, err := jwt.Sign(jwt.RS256, "rsa", payload, rsaKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identity, err := oidcService.Authenticate(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if identity.EmailVerified != tc.want {
+			t.Errorf("%s: got email verified %t, wanted %t", tc.name, identity.EmailVerified, tc.want)
+		}
+	}
+}
+
+// fakeAccountRepository Хранилище аккаунтов в памяти для проверки связывания с внешним провайдером
+type fakeAccountRepository struct {
+	repository.Account
+	accounts map[int]*entity.Account
+}
+
+func (f *fakeAccountRepository) GetByEmail(account *entity.Account) *entity.Account {
+	for _, acc := range f.accounts {
+		if acc.Email == account.Email {
+			found := *acc
+			return &found
+		}
+	}
+	return &entity.Account{}
+}
+
+func (f *fakeAccountRepository) GetByExternalId(externalId string) (*entity.Account, error) {
+	for _, acc := range f.accounts {
+		if acc.ExternalId != nil && *acc.ExternalId == externalId {
+			found := *acc
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (f *fakeAccountRepository) UpdateExternalIdentity(id int, externalId, role string) error {
+	f.accounts[id].ExternalId = &externalId
+	f.accounts[id].Role = role
+	return nil
+}
+
+func TestGetByExternalIdentityLinking(t *testing.T) {
+	accountRepo := &fakeAccountRepository{accounts: map[int]*entity.Account{
+		1: {Id: 1, Email: "user@example.com", Role: entity.AdminRole},
+	}}
+	accountService := service.NewAccountService(
+		accountRepo,
+		password.NewPasswordService(password.Params{}),
+		accountcache.NewAccountCacheService(accountcache.Params{}),
+	)
+
+	identity := &oidc.Identity{ExternalId: testIssuer + "|user-1", Email: "user@example.com", Role: entity.UserRole}
+
+	if _, httpErr := accountService.GetByExternalIdentity(identity, oidc.AccountPolicy{LinkByEmail: true}); httpErr == nil {
+		t.Errorf("account linked by unverified email")
+	}
+
+	identity.EmailVerified = true
+	if _, httpErr := accountService.GetByExternalIdentity(identity, oidc.AccountPolicy{}); httpErr == nil {
+		t.Errorf("account linked by email with linking disabled")
+	}
+	if accountRepo.accounts[1].ExternalId != nil {
+		t.Fatalf("account linked with linking disabled")
+	}
+
+	account, httpErr := accountService.GetByExternalIdentity(identity, oidc.AccountPolicy{LinkByEmail: true, SyncRole: true})
+	if httpErr != nil {
+		t.Fatal(httpErr.Err)
+	}
+	if account.Id != 1 || accountRepo.accounts[1].ExternalId == nil {
+		t.Errorf("account was not linked")
+	}
+	if account.Role != entity.AdminRole || accountRepo.accounts[1].Role != entity.AdminRole {
+		t.Errorf("role of local account changed on linking to %q", accountRepo.accounts[1].Role)
+	}
+
+	account, httpErr = accountService.GetByExternalIdentity(identity, oidc.AccountPolicy{})
+	if httpErr != nil {
+		t.Fatal(httpErr.Err)
+	}
+	if account.Role != entity.AdminRole {
+		t.Errorf("role synchronized with role sync disabled")
+	}
+
+	account, httpErr = accountService.GetByExternalIdentity(identity, oidc.AccountPolicy{SyncRole: true})
+	if httpErr != nil {
+		t.Fatal(httpErr.Err)
+	}
+	if account.Role != entity.UserRole || accountRepo.accounts[1].Role != entity.UserRole {
+		t.Errorf("got role %q, wanted %q", accountRepo.accounts[1].Role, entity.UserRole)
+	}
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"it-planet-task/internal/app/service/password"
+	"testing"
+)
+
+func TestBcryptHashAndCompare(t *testing.T) {
+	passwordService := password.NewPasswordService(password.Params{Algorithm: password.Bcrypt, BcryptCost: 4})
+
+	hash, err := passwordService.Hash("qwerty123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !passwordService.IsHashed(hash) {
+		t.Errorf("got %t, wanted %t", false, true)
+	}
+	if !passwordService.Compare(hash, "qwerty123") {
+		t.Errorf("correct password rejected")
+	}
+	if passwordService.Compare(hash, "qwerty124") {
+		t.Errorf("wrong password accepted")
+	}
+	if passwordService.NeedsRehash(hash) {
+		t.Errorf("fresh hash needs rehash")
+	}
+}
+
+func TestArgon2idHashAndCompare(t *testing.T) {
+	passwordService := password.NewPasswordService(password.Params{Algorithm: password.Argon2id, Argon2Memory: 1024})
+
+	hash, err := passwordService.Hash("qwerty123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !passwordService.Compare(hash, "qwerty123") {
+		t.Errorf("correct password rejected")
+	}
+	if passwordService.Compare(hash, "qwerty124") {
+		t.Errorf("wrong password accepted")
+	}
+	if passwordService.NeedsRehash(hash) {
+		t.Errorf("fresh hash needs rehash")
+	}
+}
+
+func TestLegacyPlaintextPassword(t *testing.T) {
+	passwordService := password.NewPasswordService(password.Params{Algorithm: password.Bcrypt, BcryptCost: 4})
+
+	if passwordService.IsHashed("qwerty123") {
+		t.Errorf("plaintext password detected as hash")
+	}
+	if !passwordService.Compare("qwerty123", "qwerty123") {
+		t.Errorf("correct legacy password rejected")
+	}
+	if passwordService.Compare("qwerty123", "qwerty124") {
+		t.Errorf("wrong legacy password accepted")
+	}
+	if !passwordService.NeedsRehash("qwerty123") {
+		t.Errorf("plaintext password does not need rehash")
+	}
+}
+
+func TestRehashOnAlgorithmChange(t *testing.T) {
+	bcryptService := password.NewPasswordService(password.Params{Algorithm: password.Bcrypt, BcryptCost: 4})
+	argon2Service := password.NewPasswordService(password.Params{Algorithm: password.Argon2id, Argon2Memory: 1024})
+
+	hash, err := bcryptService.Hash("qwerty123")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !argon2Service.Compare(hash, "qwerty123") {
+		t.Errorf("bcrypt hash rejected by argon2id service")
+	}
+	if !argon2Service.NeedsRehash(hash) {
+		t.Errorf("bcrypt hash does not need rehash for argon2id service")
+	}
+}
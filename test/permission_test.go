@@ -0,0 +1,56 @@
+package test
+
+import (
+	"it-planet-task/internal/app/service/permission"
+	"testing"
+)
+
+func TestPermissionWildcards(t *testing.T) {
+	permissionService := permission.NewPermissionService(map[string][]string{
+		"ADMIN":      {permission.All},
+		"VET":        {"animals:*", permission.LocationsRead},
+		"RESEARCHER": {permission.AnimalsRead},
+	})
+
+	cases := []struct {
+		role       string
+		permission string
+		want       bool
+	}{
+		{"ADMIN", permission.AccountsDeleteAny, true},
+		{"VET", permission.AnimalsDelete, true},
+		{"VET", permission.LocationsRead, true},
+		{"VET", permission.LocationsDelete, false},
+		{"VET", permission.AnimalTypesCreate, false},
+		{"RESEARCHER", permission.AnimalsRead, true},
+		{"RESEARCHER", permission.AnimalsUpdate, false},
+		{"UNKNOWN", permission.AnimalsRead, false},
+	}
+
+	for _, tc := range cases {
+		got := permissionService.HasPermission(tc.role, tc.permission)
+		if got != tc.want {
+			t.Errorf("%s %s: got %t, wanted %t", tc.role, tc.permission, got, tc.want)
+		}
+	}
+}
+
+func TestDefaultRolesMatchLegacyChecks(t *testing.T) {
+	permissionService := permission.NewPermissionService(permission.DefaultRoles)
+
+	if permissionService.HasPermission("USER", permission.LocationsCreate) {
+		t.Errorf("USER can create locations")
+	}
+	if !permissionService.HasPermission("CHIPPER", permission.LocationsCreate) {
+		t.Errorf("CHIPPER cant create locations")
+	}
+	if permissionService.HasPermission("CHIPPER", permission.AreasCreate) {
+		t.Errorf("CHIPPER can create areas")
+	}
+	if !permissionService.HasPermission("ADMIN", permission.AreasCreate) {
+		t.Errorf("ADMIN cant create areas")
+	}
+	if permissionService.HasPermission("USER", permission.AnimalsChip) {
+		t.Errorf("USER can be chipper")
+	}
+}
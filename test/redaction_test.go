@@ -0,0 +1,56 @@
+package test
+
+import (
+	"it-planet-task/internal/app/mapper"
+	"it-planet-task/internal/app/model/response"
+	"math"
+	"testing"
+)
+
+func TestRedactSensitiveLocation(t *testing.T) {
+	latitude, longitude := 55.751244, 37.618423
+	location := &response.Location{Id: 1, Latitude: &latitude, Longitude: &longitude}
+	viewer := &mapper.Viewer{GeohashPrecision: 5}
+
+	redacted := mapper.RedactLocationResponse(location, viewer, true)
+	if redacted.Geohash != "ucfv0" {
+		t.Errorf("got geohash %q, wanted %q", redacted.Geohash, "ucfv0")
+	}
+	if *redacted.Latitude == latitude || math.Abs(*redacted.Latitude-latitude) > 0.03 {
+		t.Errorf("latitude %v is not coarsened to the cell of %v", *redacted.Latitude, latitude)
+	}
+	if math.Abs(*redacted.Longitude-longitude) > 0.03 {
+		t.Errorf("longitude %v is not coarsened to the cell of %v", *redacted.Longitude, longitude)
+	}
+	if *location.Latitude != latitude {
+		t.Error("original response must not be modified")
+	}
+
+	if got := mapper.RedactLocationResponse(location, viewer, false); got.Geohash != "" || *got.Latitude != latitude {
+		t.Error("location without sensitive animals must not be coarsened")
+	}
+	viewer.ExactLocations = true
+	if got := mapper.RedactLocationResponse(location, viewer, true); got.Geohash != "" || *got.Latitude != latitude {
+		t.Error("viewer with exact locations permission must see exact coordinates")
+	}
+}
+
+func TestRedactAccountEmail(t *testing.T) {
+	accounts := &[]response.Account{
+		{Id: 1, Email: "own@example.com"},
+		{Id: 2, Email: "chipper@example.com"},
+	}
+
+	redacted := *mapper.RedactAccountResponses(accounts, &mapper.Viewer{AccountId: 1})
+	if redacted[0].Email != "own@example.com" {
+		t.Errorf("own email must be visible, got %q", redacted[0].Email)
+	}
+	if redacted[1].Email != "" {
+		t.Errorf("another's email must be hidden, got %q", redacted[1].Email)
+	}
+
+	redacted = *mapper.RedactAccountResponses(accounts, &mapper.Viewer{AccountId: 1, AccountEmails: true})
+	if redacted[1].Email != "chipper@example.com" {
+		t.Errorf("email must be visible with permission, got %q", redacted[1].Email)
+	}
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/model/entity"
+	"it-planet-task/internal/app/model/input"
+	"it-planet-task/internal/app/repository"
+	"it-planet-task/internal/app/service"
+	"it-planet-task/internal/app/validator/TagValidator"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tag, httpErr := TagValidator.NormalizeTag("  2023   River Survey ")
+	if httpErr != nil || tag != "2023 river survey" {
+		t.Errorf("got %q, %v", tag, httpErr)
+	}
+
+	for _, invalid := range []string{"", "   ", "a,b", strings.Repeat("x", TagValidator.MaxTagLength+1)} {
+		if _, httpErr = TagValidator.NormalizeTag(invalid); httpErr == nil {
+			t.Errorf("%q: expected error", invalid)
+		}
+	}
+}
+
+func TestAnimalFilterTags(t *testing.T) {
+	params, httpErr := filter.NewAnimalFilterParams(url.Values{
+		"tags":      {"Translocated,2023 river survey", "translocated"},
+		"tagsMatch": {"ALL"},
+	})
+	if httpErr != nil {
+		t.Fatal(httpErr)
+	}
+	if want := []string{"translocated", "2023 river survey"}; !reflect.DeepEqual(params.Tags, want) {
+		t.Errorf("got tags %v, wanted %v", params.Tags, want)
+	}
+	if params.TagsMatch != filter.AllTagsMatch {
+		t.Errorf("got tags match %q", params.TagsMatch)
+	}
+
+	params, _ = filter.NewAnimalFilterParams(url.Values{})
+	if len(params.Tags) != 0 || params.TagsMatch != filter.AnyTagsMatch {
+		t.Errorf("unexpected default tags filter %v %q", params.Tags, params.TagsMatch)
+	}
+
+	if _, httpErr = filter.NewAnimalFilterParams(url.Values{"tagsMatch": {"some"}}); httpErr == nil {
+		t.Error("expected tagsMatch error")
+	}
+}
+
+func TestValidateAnimalTagsInput(t *testing.T) {
+	animalTags := &input.AnimalTags{AnimalIds: []int{1, 2}, Add: []string{"Translocated", "translocated "}, Remove: []string{"Old"}}
+	if httpErr := TagValidator.ValidateAnimalTagsInput(animalTags); httpErr != nil {
+		t.Fatal(httpErr.Err)
+	}
+	if !reflect.DeepEqual(animalTags.Add, []string{"translocated"}) || !reflect.DeepEqual(animalTags.Remove, []string{"old"}) {
+		t.Errorf("expected normalized tags, got %v %v", animalTags.Add, animalTags.Remove)
+	}
+
+	cases := []*input.AnimalTags{
+		{Add: []string{"a"}},
+		{AnimalIds: []int{0}, Add: []string{"a"}},
+		{AnimalIds: []int{1}},
+		{AnimalIds: []int{1}, Add: []string{"a"}, Remove: []string{"A"}},
+	}
+	for _, animalTags = range cases {
+		if TagValidator.ValidateAnimalTagsInput(animalTags) == nil {
+			t.Errorf("%+v: expected error", animalTags)
+		}
+	}
+}
+
+// fakeAnimalRepository Животные в памяти с организациями для проверки доступа к группе животных
+type fakeAnimalRepository struct {
+	repository.Animal
+	animals []entity.Animal
+}
+
+func (f *fakeAnimalRepository) GetOrganizationsByIds(ids []int, scope *filter.TenantScope) (*[]entity.Animal, error) {
+	requested := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		requested[id] = true
+	}
+
+	var animals []entity.Animal
+	for _, animal := range f.animals {
+		if requested[animal.Id] && scope.CanAccess(animal.OrganizationId) {
+			animals = append(animals, animal)
+		}
+	}
+	return &animals, nil
+}
+
+func TestAnimalServiceCheckWritable(t *testing.T) {
+	organizationId, otherOrganizationId := 1, 2
+	animalService := service.NewAnimalService(&fakeAnimalRepository{animals: []entity.Animal{
+		{Id: 1, OrganizationId: &organizationId},
+		{Id: 2, OrganizationId: &organizationId},
+		{Id: 3},
+		{Id: 4, OrganizationId: &otherOrganizationId},
+	}})
+	scope := &filter.TenantScope{OrganizationId: &organizationId}
+
+	if httpErr := animalService.CheckWritable([]int{1, 2}, scope); httpErr != nil {
+		t.Errorf("unexpected error %v", httpErr.Err)
+	}
+
+	httpErr := animalService.CheckWritable([]int{1, 4, 5, 5}, scope)
+	if httpErr == nil || httpErr.StatusCode != http.StatusNotFound || !strings.Contains(httpErr.Err.Error(), "[4 5]") {
+		t.Errorf("expected not found for animals 4 and 5, got %v", httpErr)
+	}
+
+	httpErr = animalService.CheckWritable([]int{1, 3}, scope)
+	if httpErr == nil || httpErr.StatusCode != http.StatusForbidden || !strings.Contains(httpErr.Err.Error(), "[3]") {
+		t.Errorf("expected forbidden for animal 3, got %v", httpErr)
+	}
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"it-planet-task/internal/app/filter"
+	"it-planet-task/internal/app/service/permission"
+	"testing"
+)
+
+func TestTenantScopeCanAccess(t *testing.T) {
+	organizationId := 1
+	otherOrganizationId := 2
+
+	cases := []struct {
+		name  string
+		scope *filter.TenantScope
+		orgId *int
+		want  bool
+	}{
+		{"nil scope", nil, &organizationId, true},
+		{"unrestricted", &filter.TenantScope{Unrestricted: true}, &otherOrganizationId, true},
+		{"global row", &filter.TenantScope{OrganizationId: &organizationId}, nil, true},
+		{"same organization", &filter.TenantScope{OrganizationId: &organizationId}, &organizationId, true},
+		{"other organization", &filter.TenantScope{OrganizationId: &organizationId}, &otherOrganizationId, false},
+		{"no active organization", &filter.TenantScope{}, &organizationId, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.scope.CanAccess(tc.orgId); got != tc.want {
+			t.Errorf("%s: got %v, wanted %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestTenantScopeCanWrite Участник организации с ролью ADMIN не может изменять общие данные без организации
+func TestTenantScopeCanWrite(t *testing.T) {
+	organizationId := 1
+	otherOrganizationId := 2
+
+	cases := []struct {
+		name  string
+		scope *filter.TenantScope
+		orgId *int
+		want  bool
+	}{
+		{"nil scope", nil, nil, true},
+		{"unrestricted", &filter.TenantScope{Unrestricted: true}, nil, true},
+		{"global row without organization", &filter.TenantScope{}, nil, true},
+		{"global row in organization", &filter.TenantScope{OrganizationId: &organizationId}, nil, false},
+		{"same organization", &filter.TenantScope{OrganizationId: &organizationId}, &organizationId, true},
+		{"other organization", &filter.TenantScope{OrganizationId: &organizationId}, &otherOrganizationId, false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.scope.CanWrite(tc.orgId); got != tc.want {
+			t.Errorf("%s: got %v, wanted %v", tc.name, got, tc.want)
+		}
+	}
+
+	globalRow := (*int)(nil)
+	partnerAdmin := &filter.TenantScope{OrganizationId: &organizationId}
+	if !partnerAdmin.CanAccess(globalRow) || partnerAdmin.CanWrite(globalRow) {
+		t.Error("global rows must stay readable but read-only under an organization role")
+	}
+}
+
+func TestPlatformPermissions(t *testing.T) {
+	for _, platformPermission := range []string{permission.OrganizationsManage, permission.AnimalTypesCreate, permission.AnimalTypesUpdate, permission.AnimalTypesDelete, permission.AnimalTypesProtect} {
+		if !permission.IsPlatform(platformPermission) {
+			t.Errorf("%s should be checked against the account role only", platformPermission)
+		}
+	}
+	if permission.IsPlatform(permission.AnimalsCreate) {
+		t.Errorf("%s should respect the organization role", permission.AnimalsCreate)
+	}
+}
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"it-planet-task/internal/app/service/throttle"
+	"testing"
+	"time"
+)
+
+func TestThrottleExponentialBackoff(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	throttleService := throttle.NewThrottleServiceWithClock(throttle.Params{
+		FreeAttempts:     2,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute,
+		LockoutThreshold: 10,
+		LockoutDuration:  time.Hour,
+		ResetAfter:       time.Hour,
+	}, func() time.Time { return now })
+	key := throttle.NewEmailKey("User@Example.com")
+
+	throttleService.Failure(key)
+	throttleService.Failure(key)
+	if got := throttleService.RetryAfter(key); got != 0 {
+		t.Errorf("got %v, wanted %v", got, time.Duration(0))
+	}
+
+	throttleService.Failure(key)
+	if got := throttleService.RetryAfter(throttle.NewEmailKey("user@example.com")); got != time.Second {
+		t.Errorf("got %v, wanted %v", got, time.Second)
+	}
+
+	throttleService.Failure(key)
+	if got := throttleService.RetryAfter(key); got != 2*time.Second {
+		t.Errorf("got %v, wanted %v", got, 2*time.Second)
+	}
+
+	throttleService.Success(key)
+	if got := throttleService.RetryAfter(key); got != 0 {
+		t.Errorf("got %v, wanted %v", got, time.Duration(0))
+	}
+}
+
+func TestThrottleLockout(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	throttleService := throttle.NewThrottleServiceWithClock(throttle.Params{
+		FreeAttempts:     1,
+		LockoutThreshold: 3,
+		LockoutDuration:  time.Hour,
+	}, func() time.Time { return now })
+	key := throttle.NewIpKey("10.0.0.1")
+
+	for i := 0; i < 3; i++ {
+		throttleService.Failure(key)
+	}
+
+	lockouts := throttleService.Lockouts()
+	if len(lockouts) != 1 || !lockouts[0].Locked {
+		t.Fatalf("got %v, wanted one locked key", lockouts)
+	}
+	if got := throttleService.RetryAfter(key); got != time.Hour {
+		t.Errorf("got %v, wanted %v", got, time.Hour)
+	}
+
+	if !throttleService.Clear(key) {
+		t.Errorf("lockout was not cleared")
+	}
+	if got := throttleService.RetryAfter(key); got != 0 {
+		t.Errorf("got %v, wanted %v", got, time.Duration(0))
+	}
+}
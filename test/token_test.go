@@ -0,0 +1,70 @@
+package test
+
+import (
+	"it-planet-task/internal/app/service/token"
+	"it-planet-task/pkg/jwt"
+	"testing"
+	"time"
+)
+
+func TestAccessTokenRoundTrip(t *testing.T) {
+	tokenService := token.NewTokenService(token.Params{Key: []byte("secret"), Issuer: "it-planet-task"})
+
+	accessToken, _, err := tokenService.NewAccessToken(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tokenService.ParseAccessToken(accessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 42 {
+		t.Errorf("got %d, wanted %d", got, 42)
+	}
+}
+
+func TestAccessTokenWrongKey(t *testing.T) {
+	tokenService := token.NewTokenService(token.Params{Key: []byte("secret")})
+	otherTokenService := token.NewTokenService(token.Params{Key: []byte("other secret")})
+
+	accessToken, _, err := tokenService.NewAccessToken(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = otherTokenService.ParseAccessToken(accessToken)
+	if err != jwt.ErrInvalidSignature {
+		t.Errorf("got %v, wanted %v", err, jwt.ErrInvalidSignature)
+	}
+}
+
+func TestExpiredAccessToken(t *testing.T) {
+	accessToken, err := jwt.SignHS256(&jwt.Claims{Subject: "42", ExpiresAt: time.Now().Add(-time.Minute).Unix()}, []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = jwt.ParseHS256(accessToken, []byte("secret"), "")
+	if err != jwt.ErrTokenExpired {
+		t.Errorf("got %v, wanted %v", err, jwt.ErrTokenExpired)
+	}
+}
+
+func TestRefreshTokenHash(t *testing.T) {
+	tokenService := token.NewTokenService(token.Params{Key: []byte("secret")})
+
+	refreshToken, expiresAt, err := tokenService.NewRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !expiresAt.After(time.Now()) {
+		t.Errorf("refresh token already expired")
+	}
+	if tokenService.HashToken(refreshToken) == refreshToken {
+		t.Errorf("refresh token hash equals token")
+	}
+	if tokenService.HashToken(refreshToken) != tokenService.HashToken(refreshToken) {
+		t.Errorf("refresh token hash is not deterministic")
+	}
+}